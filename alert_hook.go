@@ -0,0 +1,247 @@
+package go_logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxDedupKeyLength is the longest dedup_key accepted by the PagerDuty Events API v2.
+const maxDedupKeyLength = 255
+
+type AlertFormat int
+
+const (
+	// AlertSlack posts {"text": ...} as expected by Slack and compatible incoming webhooks.
+	AlertSlack AlertFormat = iota
+	// AlertPagerDuty posts a trigger event of the PagerDuty Events API v2.
+	AlertPagerDuty
+	// AlertJSON posts the event encoded by the default JSONEncoder.
+	AlertJSON
+)
+
+// AlertHook posts a summary of events at or above its level to a webhook. Use it as post-write hook:
+//
+//	logger.PostWrite(NewAlertHook(AlertSlack, url, ERROR).Fire)
+//
+// Events with the same logger, level and message are sent once per dedup window, and all alerts are
+// rate limited, so an error storm results in a single alert. Requests are sent in the background;
+// Close waits for pending ones.
+type AlertHook struct {
+	url        string
+	format     AlertFormat
+	client     *http.Client
+	level      Level
+	routingKey string
+	source     string
+	dedup      time.Duration
+	limiter    *rateLimiter
+	onError    func(error)
+	mutex      sync.Mutex
+	sent       map[string]time.Time
+	repeated   map[string]int
+	queue      chan []byte // nil once closed
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+func NewAlertHook(format AlertFormat, url string, level Level) *AlertHook {
+	source, _ := os.Hostname()
+	hook := &AlertHook{
+		url:      url,
+		format:   format,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		level:    level,
+		source:   source,
+		dedup:    5 * time.Minute,
+		sent:     map[string]time.Time{},
+		repeated: map[string]int{},
+		queue:    make(chan []byte, 16),
+		done:     make(chan struct{}),
+	}
+	hook.RateLimit(10, time.Minute)
+	go hook.run(hook.queue)
+	return hook
+}
+
+func (hook *AlertHook) Client(client *http.Client) *AlertHook {
+	hook.client = client
+	return hook
+}
+
+// RoutingKey sets the integration key required by PagerDuty.
+func (hook *AlertHook) RoutingKey(key string) *AlertHook {
+	hook.routingKey = key
+	return hook
+}
+
+// Source sets the source reported to PagerDuty, the host name by default.
+func (hook *AlertHook) Source(source string) *AlertHook {
+	hook.source = source
+	return hook
+}
+
+// Dedup sets the window in which identical events are alerted once, 5 minutes by default.
+func (hook *AlertHook) Dedup(window time.Duration) *AlertHook {
+	hook.dedup = window
+	return hook
+}
+
+// RateLimit limits the hook to alerts per duration, 10 per minute by default.
+func (hook *AlertHook) RateLimit(alerts int, per time.Duration) *AlertHook {
+	hook.limiter = &rateLimiter{
+		rate:   float64(alerts) / per.Seconds(),
+		burst:  float64(alerts),
+		tokens: float64(alerts),
+		last:   time.Now(),
+	}
+	return hook
+}
+
+// OnError is called from the background goroutine for failed requests, and from Fire with
+// ErrAlertDropped for alerts dropped because the queue is full.
+func (hook *AlertHook) OnError(callback func(error)) *AlertHook {
+	hook.onError = callback
+	return hook
+}
+
+// ErrAlertDropped is passed to the OnError callback of an AlertHook for alerts dropped because requests
+// could not keep up.
+var ErrAlertDropped = errors.New("go_logger: alert dropped, queue full")
+
+// Fire alerts event unless it is below the level of the hook, a duplicate or rate limited. Events are
+// only recorded for deduplication once their alert is queued, so a dropped alert is sent again.
+func (hook *AlertHook) Fire(event *Event) {
+	if event.Level < hook.level {
+		return
+	}
+	key := event.Level.Long() + " " + event.Logger + ": " + event.Message
+	hook.mutex.Lock()
+	if last, ok := hook.sent[key]; ok && event.Timestamp.Sub(last) < hook.dedup {
+		hook.repeated[key]++
+		hook.mutex.Unlock()
+		return
+	}
+	allowed, _ := hook.limiter.allow(event.Timestamp, false)
+	if !allowed || hook.queue == nil {
+		hook.mutex.Unlock()
+		return
+	}
+	select {
+	case hook.queue <- hook.payload(event, key, hook.repeated[key]):
+	default:
+		hook.mutex.Unlock()
+		if hook.onError != nil {
+			hook.onError(ErrAlertDropped)
+		}
+		return
+	}
+	if len(hook.sent) > 1024 {
+		for k, last := range hook.sent {
+			if event.Timestamp.Sub(last) >= hook.dedup {
+				delete(hook.sent, k)
+				delete(hook.repeated, k)
+			}
+		}
+	}
+	hook.sent[key] = event.Timestamp
+	delete(hook.repeated, key)
+	hook.mutex.Unlock()
+}
+
+func (hook *AlertHook) payload(event *Event, key string, repeated int) []byte {
+	summary := buffer{}
+	if event.Logger != "" {
+		summary.WriteByte('[')
+		summary.WriteString(event.Logger)
+		summary.WriteString("] ")
+	}
+	summary.WriteString(event.Message)
+	if event.Err != nil {
+		summary.WriteString(": ")
+		summary.WriteString(event.Err.Error())
+	}
+	if repeated > 0 {
+		summary.WriteString(" (repeated ")
+		summary.WriteString(strconv.Itoa(repeated))
+		summary.WriteString(" times)")
+	}
+	sb := buffer{}
+	switch hook.format {
+	case AlertPagerDuty:
+		severity := "error"
+		switch {
+		case event.Level >= FATAL:
+			severity = "critical"
+		case event.Level == WARN:
+			severity = "warning"
+		case event.Level < WARN:
+			severity = "info"
+		}
+		sb.WriteString(`{"routing_key":`)
+		writeJSONString(&sb, hook.routingKey)
+		sb.WriteString(`,"event_action":"trigger","dedup_key":`)
+		dedupKey, _ := truncateValue(key, maxDedupKeyLength)
+		writeJSONString(&sb, dedupKey)
+		sb.WriteString(`,"payload":{"summary":`)
+		writeJSONString(&sb, summary.String())
+		sb.WriteString(`,"source":`)
+		writeJSONString(&sb, hook.source)
+		sb.WriteString(`,"severity":"`)
+		sb.WriteString(severity)
+		sb.WriteString(`","timestamp":"`)
+		sb.WriteString(event.Timestamp.Format(time.RFC3339))
+		sb.WriteString(`","component":`)
+		writeJSONString(&sb, event.Logger)
+		sb.WriteString(`,"custom_details":{`)
+		first := true
+		writeJSONFields(&sb, event.Fields, &first)
+		sb.WriteString("}}}")
+	case AlertJSON:
+		defaultJSONEncoder.encode(&sb, event)
+	default:
+		sb.WriteString(`{"text":`)
+		writeJSONString(&sb, "*"+event.Level.Long()+"* "+summary.String())
+		sb.WriteByte('}')
+	}
+	return []byte(sb.String())
+}
+
+func (hook *AlertHook) run(queue <-chan []byte) {
+	defer close(hook.done)
+	for payload := range queue {
+		if err := hook.post(payload); err != nil && hook.onError != nil {
+			hook.onError(err)
+		}
+	}
+}
+
+func (hook *AlertHook) post(payload []byte) error {
+	response, err := hook.client.Post(hook.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+	if response.StatusCode >= 300 {
+		return statusError(response.StatusCode)
+	}
+	return nil
+}
+
+// Close stops the hook after sending pending alerts. Alerts fired afterwards are dropped.
+func (hook *AlertHook) Close() error {
+	hook.closeOnce.Do(func() {
+		hook.mutex.Lock()
+		close(hook.queue)
+		hook.queue = nil
+		hook.mutex.Unlock()
+	})
+	<-hook.done
+	return nil
+}
@@ -0,0 +1,172 @@
+package go_logger_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// alertServer records the bodies posted to it; requests wait while blocked is open.
+type alertServer struct {
+	mutex   sync.Mutex
+	bodies  []string
+	blocked chan struct{}
+}
+
+func (server *alertServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if server.blocked != nil {
+		<-server.blocked
+	}
+	body, _ := io.ReadAll(r.Body)
+	server.mutex.Lock()
+	server.bodies = append(server.bodies, string(body))
+	server.mutex.Unlock()
+}
+
+func (server *alertServer) received() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]string(nil), server.bodies...)
+}
+
+func alertEvent(msg string) *golog.Event {
+	return &golog.Event{Timestamp: time.Now(), Level: golog.ERROR, Logger: "api", Message: msg}
+}
+
+func TestAlertHook(t *testing.T) {
+	tests := []struct {
+		name   string
+		format golog.AlertFormat
+		fire   []string
+		want   []string
+	}{
+		{
+			name:   "slack",
+			format: golog.AlertSlack,
+			fire:   []string{"db down"},
+			want:   []string{`{"text":"*ERROR* [api] db down"}`},
+		},
+		{
+			name:   "deduplicated",
+			format: golog.AlertSlack,
+			fire:   []string{"db down", "db down", "disk full"},
+			want:   []string{`{"text":"*ERROR* [api] db down"}`, `{"text":"*ERROR* [api] disk full"}`},
+		},
+		{
+			name:   "pagerduty",
+			format: golog.AlertPagerDuty,
+			fire:   []string{"db down"},
+			want:   []string{`"dedup_key":"ERROR api: db down"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &alertServer{}
+			httpServer := httptest.NewServer(server)
+			defer httpServer.Close()
+			hook := golog.NewAlertHook(tt.format, httpServer.URL, golog.WARN)
+			for _, msg := range tt.fire {
+				hook.Fire(alertEvent(msg))
+			}
+			_ = hook.Close()
+			got := server.received()
+			if len(got) != len(tt.want) {
+				t.Fatalf("posted %q, want %d alerts", got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("alert %d is %s, want it to contain %s", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestAlertHookDedupKeyLength(t *testing.T) {
+	server := &alertServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	hook := golog.NewAlertHook(golog.AlertPagerDuty, httpServer.URL, golog.WARN)
+	hook.Fire(alertEvent(strings.Repeat("x", 1000)))
+	_ = hook.Close()
+	got := server.received()
+	if len(got) != 1 {
+		t.Fatalf("posted %d alerts, want 1", len(got))
+	}
+	var body struct {
+		DedupKey string `json:"dedup_key"`
+	}
+	if err := json.Unmarshal([]byte(got[0]), &body); err != nil {
+		t.Fatal(err)
+	}
+	if n := len([]rune(body.DedupKey)); n == 0 || n > 255 {
+		t.Errorf("dedup_key has %d characters, want 1 to 255", n)
+	}
+}
+
+func TestAlertHookQueueFull(t *testing.T) {
+	server := &alertServer{blocked: make(chan struct{})}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	var mutex sync.Mutex
+	var errs []error
+	hook := golog.NewAlertHook(golog.AlertSlack, httpServer.URL, golog.WARN).
+		RateLimit(1000, time.Minute).
+		OnError(func(err error) {
+			mutex.Lock()
+			errs = append(errs, err)
+			mutex.Unlock()
+		})
+	dropped := ""
+	for i := 0; i < 100 && dropped == ""; i++ {
+		msg := "alert " + strconv.Itoa(i)
+		hook.Fire(alertEvent(msg))
+		mutex.Lock()
+		if len(errs) > 0 {
+			dropped = msg
+		}
+		mutex.Unlock()
+	}
+	if dropped == "" {
+		t.Fatal("no alert was dropped with a blocked webhook")
+	}
+	if !errors.Is(errs[0], golog.ErrAlertDropped) {
+		t.Errorf("OnError got %v, want ErrAlertDropped", errs[0])
+	}
+	close(server.blocked)
+	// the dropped alert was not recorded as sent, so firing it again succeeds once the queue drains
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mutex.Lock()
+		errs = nil
+		mutex.Unlock()
+		hook.Fire(alertEvent(dropped))
+		mutex.Lock()
+		queued := len(errs) == 0
+		mutex.Unlock()
+		if queued {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("dropped alert could not be fired again")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	_ = hook.Close()
+	found := false
+	for _, body := range server.received() {
+		found = found || strings.Contains(body, dropped+`"`)
+	}
+	if !found {
+		t.Errorf("dropped alert %q was never posted", dropped)
+	}
+}
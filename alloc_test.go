@@ -0,0 +1,37 @@
+package go_logger_test
+
+import (
+	"io"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+var raceEnabled bool
+
+func TestAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not counted reliably under the race detector")
+	}
+	const budget = 2
+	tests := []struct {
+		name   string
+		format golog.Format
+		log    func(logger *golog.Logger)
+	}{
+		{"plain", golog.PLAIN, func(logger *golog.Logger) { logger.Info("request handled") }},
+		{"json", golog.JSON, func(logger *golog.Logger) { logger.Info("request handled") }},
+		{"json fields", golog.JSON, func(logger *golog.Logger) {
+			logger.InfoEvent().Str("path", "/users").Int("status", 200).Msg("request handled")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := golog.NewLogger("alloc").Out(io.Discard).Colorized(false).Format(tt.format).Level(golog.INFO)
+			allocs := testing.AllocsPerRun(100, func() { tt.log(logger) })
+			if allocs > budget {
+				t.Errorf("%v allocations per event, want at most %d", allocs, budget)
+			}
+		})
+	}
+}
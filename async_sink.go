@@ -0,0 +1,160 @@
+package go_logger
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+type OverflowPolicy int
+
+const (
+	// Block makes the logging goroutine wait until the queue has room.
+	Block OverflowPolicy = iota
+	// DropOldest discards the oldest queued event to make room for the new one.
+	DropOldest
+	// DropNewest discards the new event if the queue is full.
+	DropNewest
+)
+
+var ErrSinkClosed = errors.New("go_logger: sink closed")
+
+// AsyncSink queues events in a bounded channel and writes them to the wrapped sink from a
+// background goroutine. Flush and Close wait until everything queued before them has been written.
+// Errors of the wrapped sink cannot reach the logging goroutine; they are counted by WriteErrors, sent
+// to WriteErrorChannel and passed to OnError.
+type AsyncSink struct {
+	sink    Sink
+	policy  OverflowPolicy
+	queue   chan *Event
+	flushes chan chan error
+	mutex   sync.RWMutex
+	closed  bool
+	done    chan struct{}
+	dropped atomic.Uint64
+	onError func(error, *Event)
+}
+
+func NewAsyncSink(sink Sink, size int, policy OverflowPolicy) *AsyncSink {
+	if size < 1 {
+		size = 1
+	}
+	async := &AsyncSink{
+		sink:    sink,
+		policy:  policy,
+		queue:   make(chan *Event, size),
+		flushes: make(chan chan error),
+		done:    make(chan struct{}),
+	}
+	go async.run()
+	return async
+}
+
+// OnError sets a callback invoked from the background goroutine for every event the wrapped sink
+// failed to write. The event must not be retained after the callback returns.
+func (async *AsyncSink) OnError(callback func(error, *Event)) *AsyncSink {
+	async.onError = callback
+	return async
+}
+
+func (async *AsyncSink) run() {
+	defer close(async.done)
+	for {
+		select {
+		case event, ok := <-async.queue:
+			if !ok {
+				return
+			}
+			async.write(event)
+		case flushed := <-async.flushes:
+			// everything queued before the flush is in the queue already
+			for n := len(async.queue); n > 0; n-- {
+				async.write(<-async.queue)
+			}
+			flushed <- async.sink.Flush()
+		}
+	}
+}
+
+func (async *AsyncSink) write(event *Event) {
+	if err := async.sink.Write(event); err != nil {
+		reportWriteError(err, event)
+		if async.onError != nil {
+			async.onError(err, event)
+		}
+	}
+}
+
+func (async *AsyncSink) Write(event *Event) error {
+	async.mutex.RLock()
+	defer async.mutex.RUnlock()
+	if async.closed {
+		return ErrSinkClosed
+	}
+	event = event.Clone()
+	switch async.policy {
+	case DropNewest:
+		select {
+		case async.queue <- event:
+		default:
+			async.dropped.Add(1)
+			countDropped(dropOverflow)
+		}
+	case DropOldest:
+		for {
+			select {
+			case async.queue <- event:
+				return nil
+			default:
+			}
+			select {
+			case <-async.queue:
+				async.dropped.Add(1)
+				countDropped(dropOverflow)
+			default:
+			}
+		}
+	default:
+		async.queue <- event
+	}
+	return nil
+}
+
+// Dropped returns the number of events discarded because the queue was full.
+func (async *AsyncSink) Dropped() uint64 {
+	return async.dropped.Load()
+}
+
+func (async *AsyncSink) Flush() error {
+	async.mutex.RLock()
+	if async.closed {
+		async.mutex.RUnlock()
+		return ErrSinkClosed
+	}
+	flushed := make(chan error, 1)
+	async.flushes <- flushed
+	async.mutex.RUnlock()
+	return <-flushed
+}
+
+// Close writes all queued events, stops the background goroutine and closes the wrapped sink.
+func (async *AsyncSink) Close() error {
+	async.mutex.Lock()
+	if async.closed {
+		async.mutex.Unlock()
+		return ErrSinkClosed
+	}
+	async.closed = true
+	close(async.queue)
+	async.mutex.Unlock()
+	<-async.done
+	return async.sink.Close()
+}
+
+// Reopen forwards to the wrapped sink if it implements Reopener.
+func (async *AsyncSink) Reopen() error {
+	if reopener, ok := async.sink.(Reopener); ok {
+		return reopener.Reopen()
+	}
+	return nil
+}
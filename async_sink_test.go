@@ -0,0 +1,144 @@
+package go_logger_test
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// gatedSink records the messages it writes; writes wait while the gate is closed and report on started.
+type gatedSink struct {
+	mutex    sync.Mutex
+	messages []string
+	gate     chan struct{}
+	started  chan struct{}
+	err      error
+}
+
+func newGatedSink(open bool) *gatedSink {
+	sink := &gatedSink{gate: make(chan struct{}), started: make(chan struct{}, 100)}
+	if open {
+		close(sink.gate)
+	}
+	return sink
+}
+
+func (sink *gatedSink) Write(event *golog.Event) error {
+	sink.started <- struct{}{}
+	<-sink.gate
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.messages = append(sink.messages, event.Message)
+	return sink.err
+}
+
+func (sink *gatedSink) Flush() error { return nil }
+func (sink *gatedSink) Close() error { return nil }
+
+func (sink *gatedSink) written() []string {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	return append([]string(nil), sink.messages...)
+}
+
+func TestAsyncSink(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      golog.OverflowPolicy
+		open        bool
+		want        []string
+		wantDropped uint64
+	}{
+		{name: "block", policy: golog.Block, open: true, want: []string{"0", "1", "2", "3", "4"}},
+		{name: "drop newest", policy: golog.DropNewest, want: []string{"0", "1", "2"}, wantDropped: 2},
+		{name: "drop oldest", policy: golog.DropOldest, want: []string{"0", "3", "4"}, wantDropped: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := newGatedSink(tt.open)
+			async := golog.NewAsyncSink(sink, 2, tt.policy)
+			logger := golog.NewLogger("async").Sinks(async).Level(golog.INFO)
+			logger.Info("0")
+			<-sink.started // the background goroutine holds event 0, the queue is empty
+			for _, msg := range []string{"1", "2", "3", "4"} {
+				logger.Info(msg)
+			}
+			if !tt.open {
+				close(sink.gate)
+			}
+			if err := async.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := sink.written(); !slices.Equal(got, tt.want) {
+				t.Errorf("written %v, want %v", got, tt.want)
+			}
+			if async.Dropped() != tt.wantDropped {
+				t.Errorf("%d dropped, want %d", async.Dropped(), tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestAsyncSinkErrors(t *testing.T) {
+	sink := newGatedSink(true)
+	sink.err = errors.New("disk full")
+	var reported []string
+	async := golog.NewAsyncSink(sink, 4, golog.Block).OnError(func(err error, event *golog.Event) {
+		reported = append(reported, event.Message+": "+err.Error())
+	})
+	before := golog.WriteErrors()
+	logger := golog.NewLogger("async").Sinks(async).Level(golog.INFO)
+	logger.Info("lost")
+	if err := async.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if len(reported) != 1 || reported[0] != "lost: disk full" {
+		t.Errorf("reported %v", reported)
+	}
+	if golog.WriteErrors() != before+1 {
+		t.Errorf("%d write errors counted, want 1", golog.WriteErrors()-before)
+	}
+	_ = async.Close()
+}
+
+func TestAsyncSinkFlushWhileDropping(t *testing.T) {
+	sink := newGatedSink(true)
+	async := golog.NewAsyncSink(sink, 1, golog.DropOldest)
+	logger := golog.NewLogger("async").Sinks(async).Level(golog.INFO)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wg := sync.WaitGroup{}
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 500; j++ {
+					logger.Info("event")
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					_ = async.Flush()
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+	go func() {
+		for range sink.started {
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("flush and logging deadlocked")
+	}
+	_ = async.Close()
+	close(sink.started)
+}
@@ -0,0 +1,145 @@
+package go_logger
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// maxPendingBatches is the number of full batches waiting for the background goroutine before the
+// oldest is dropped.
+const maxPendingBatches = 4
+
+// ErrBatchDropped is passed to the error handlers of batching sinks for batches dropped because
+// sending could not keep up with logging.
+var ErrBatchDropped = errors.New("go_logger: batch dropped, sending cannot keep up")
+
+// batcher collects events and hands them to send once size events are pending or interval has passed.
+// Full batches are sent by a background goroutine, so logging never waits for send and its retries.
+// If maxPendingBatches full batches are still waiting, the oldest is dropped and passed to onError with
+// ErrBatchDropped. Sends are serialized, so batches are delivered in order. Failed batches are passed
+// to onError.
+type batcher struct {
+	mutex     sync.Mutex
+	sendMutex sync.Mutex
+	events    []*Event
+	pending   [][]*Event
+	size      int
+	send      func([]*Event) error
+	onError   func(error, []*Event)
+	ready     chan struct{}
+	done      chan struct{}
+	stopped   sync.WaitGroup
+	closed    bool
+}
+
+func newBatcher(size int, interval time.Duration, send func([]*Event) error) *batcher {
+	if size < 1 {
+		size = 1
+	}
+	b := &batcher{size: size, send: send, ready: make(chan struct{}, 1), done: make(chan struct{})}
+	b.stopped.Add(1)
+	go b.run(interval)
+	return b
+}
+
+func (b *batcher) run(interval time.Duration) {
+	defer b.stopped.Done()
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-b.ready:
+			_ = b.sendPending(false)
+		case <-tick:
+			_ = b.flush()
+		}
+	}
+}
+
+func (b *batcher) add(event *Event) error {
+	b.mutex.Lock()
+	if b.closed {
+		b.mutex.Unlock()
+		return ErrSinkClosed
+	}
+	b.events = append(b.events, event.Clone())
+	if len(b.events) < b.size {
+		b.mutex.Unlock()
+		return nil
+	}
+	var dropped []*Event
+	if len(b.pending) == maxPendingBatches {
+		dropped = b.pending[0]
+		b.pending = b.pending[1:]
+	}
+	b.pending = append(b.pending, b.events)
+	b.events = nil
+	b.mutex.Unlock()
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+	if dropped != nil {
+		for range dropped {
+			countDropped(dropOverflow)
+		}
+		if b.onError != nil {
+			b.onError(ErrBatchDropped, dropped)
+		}
+	}
+	return nil
+}
+
+// sendPending sends the full batches and, with all, the events collected since, and returns the last
+// error.
+func (b *batcher) sendPending(all bool) error {
+	b.sendMutex.Lock()
+	defer b.sendMutex.Unlock()
+	var err error
+	for {
+		b.mutex.Lock()
+		var events []*Event
+		if len(b.pending) > 0 {
+			events = b.pending[0]
+			b.pending = b.pending[1:]
+		} else if all {
+			events = b.events
+			b.events = nil
+			all = false
+		}
+		b.mutex.Unlock()
+		if len(events) == 0 {
+			return err
+		}
+		if sendErr := b.send(events); sendErr != nil {
+			err = sendErr
+			if b.onError != nil {
+				b.onError(sendErr, events)
+			}
+		}
+	}
+}
+
+func (b *batcher) flush() error {
+	return b.sendPending(true)
+}
+
+func (b *batcher) close() error {
+	b.mutex.Lock()
+	if b.closed {
+		b.mutex.Unlock()
+		return ErrSinkClosed
+	}
+	b.closed = true
+	b.mutex.Unlock()
+	close(b.done)
+	b.stopped.Wait()
+	return b.flush()
+}
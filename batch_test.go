@@ -0,0 +1,136 @@
+package go_logger_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// recordingProducer keeps the messages of every batch.
+type recordingProducer struct {
+	mutex   sync.Mutex
+	batches [][]string
+}
+
+func (producer *recordingProducer) SendMessages(messages []golog.KafkaMessage) error {
+	batch := make([]string, len(messages))
+	for i, message := range messages {
+		batch[i] = string(message.Value)
+	}
+	producer.mutex.Lock()
+	defer producer.mutex.Unlock()
+	producer.batches = append(producer.batches, batch)
+	return nil
+}
+
+func (producer *recordingProducer) sizes() []int {
+	producer.mutex.Lock()
+	defer producer.mutex.Unlock()
+	var sizes []int
+	for _, batch := range producer.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+func TestBatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		interval time.Duration
+		events   int
+		finish   func(sink *golog.KafkaSink, producer *recordingProducer)
+		want     []int
+	}{
+		{
+			name: "flush sends full and partial batches",
+			size: 2, events: 5,
+			finish: func(sink *golog.KafkaSink, _ *recordingProducer) { _ = sink.Flush() },
+			want:   []int{2, 2, 1},
+		},
+		{
+			name: "close sends the rest",
+			size: 10, events: 3,
+			finish: func(sink *golog.KafkaSink, _ *recordingProducer) { _ = sink.Close() },
+			want:   []int{3},
+		},
+		{
+			name: "interval sends without flush",
+			size: 10, interval: 5 * time.Millisecond, events: 3,
+			finish: func(_ *golog.KafkaSink, producer *recordingProducer) {
+				for deadline := time.Now().Add(5 * time.Second); len(producer.sizes()) == 0 && time.Now().Before(deadline); {
+					time.Sleep(time.Millisecond)
+				}
+			},
+			want: []int{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &recordingProducer{}
+			sink := golog.NewKafkaSink(producer, "logs", tt.size, tt.interval)
+			logger := golog.NewLogger("batch").Sinks(sink).Level(golog.INFO)
+			for i := 0; i < tt.events; i++ {
+				logger.Infof("event %d", i)
+			}
+			tt.finish(sink, producer)
+			sizes := producer.sizes()
+			if len(sizes) != len(tt.want) {
+				t.Fatalf("batches of %v, want %v", sizes, tt.want)
+			}
+			for i := range sizes {
+				if sizes[i] != tt.want[i] {
+					t.Fatalf("batches of %v, want %v", sizes, tt.want)
+				}
+			}
+			n := 0
+			for _, batch := range producer.batches {
+				for _, message := range batch {
+					if want := `"message":"event ` + strconv.Itoa(n) + `"`; !strings.Contains(message, want) {
+						t.Errorf("message %d is %s", n, message)
+					}
+					n++
+				}
+			}
+			_ = sink.Close()
+		})
+	}
+}
+
+func TestBatcherDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	var dropped atomic.Int64
+	sink := golog.NewHTTPSink(server.URL, 1, 0).OnError(func(err error, events []*golog.Event) {
+		if errors.Is(err, golog.ErrBatchDropped) {
+			dropped.Add(int64(len(events)))
+		}
+	})
+	logger := golog.NewLogger("batch").Sinks(sink).Level(golog.INFO)
+
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		logger.Info("while the endpoint hangs")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("logging took %v while the endpoint hangs", elapsed)
+	}
+	if dropped.Load() == 0 {
+		t.Error("no batch dropped")
+	}
+	close(release)
+	if err := sink.Close(); err != nil {
+		t.Error(err)
+	}
+}
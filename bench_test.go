@@ -0,0 +1,49 @@
+package go_logger_test
+
+import (
+	"io"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func BenchmarkDisabled(b *testing.B) {
+	logger := golog.NewLogger("bench").Out(io.Discard).Level(golog.WARN)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request handled")
+		logger.Infof("request %d handled", i)
+	}
+}
+
+func BenchmarkPlain(b *testing.B) {
+	logger := golog.NewLogger("bench").Out(io.Discard).Colorized(false).Level(golog.INFO)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request handled")
+	}
+}
+
+func BenchmarkJSON(b *testing.B) {
+	logger := golog.NewLogger("bench").Out(io.Discard).Format(golog.JSON).Level(golog.INFO)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request handled")
+	}
+}
+
+func BenchmarkJSONFields(b *testing.B) {
+	logger := golog.NewLogger("bench").Out(io.Discard).Format(golog.JSON).Level(golog.INFO)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.InfoEvent().Str("path", "/users").Int("status", 200).Msg("request handled")
+	}
+}
+
+func BenchmarkDiscardSink(b *testing.B) {
+	logger := golog.NewLogger("bench").Sinks(golog.Discard).Level(golog.INFO)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request handled")
+	}
+}
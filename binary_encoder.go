@@ -0,0 +1,220 @@
+package go_logger
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MsgpackEncoder writes each event as a MessagePack map with the same entries as JSONEncoder.
+// Encoded events delimit themselves, so a stream is their plain concatenation without newlines.
+// Timestamps use the timestamp extension type and keep nanoseconds.
+type MsgpackEncoder struct {
+	Keys JSONKeys
+}
+
+func NewMsgpackEncoder() *MsgpackEncoder {
+	return &MsgpackEncoder{Keys: DefaultJSONKeys}
+}
+
+func (encoder *MsgpackEncoder) Encode(sb *strings.Builder, event *Event) {
+	encodeTo(sb, encoder, event)
+}
+
+func (encoder *MsgpackEncoder) encode(sb *buffer, event *Event) {
+	var buf [512]byte
+	sb.Write(appendBinaryEvent(buf[:0], msgpackFormat{}, encoder.Keys, event))
+}
+
+// CBOREncoder writes each event as a CBOR map with the same entries as JSONEncoder, so a stream is a
+// CBOR sequence (RFC 8742). Timestamps are tagged date/time strings and keep nanoseconds.
+type CBOREncoder struct {
+	Keys JSONKeys
+}
+
+func NewCBOREncoder() *CBOREncoder {
+	return &CBOREncoder{Keys: DefaultJSONKeys}
+}
+
+func (encoder *CBOREncoder) Encode(sb *strings.Builder, event *Event) {
+	encodeTo(sb, encoder, event)
+}
+
+func (encoder *CBOREncoder) encode(sb *buffer, event *Event) {
+	var buf [512]byte
+	sb.Write(appendBinaryEvent(buf[:0], cborFormat{}, encoder.Keys, event))
+}
+
+// binaryFormat abstracts the wire format of the binary encoders.
+type binaryFormat interface {
+	appendMapHeader(buf []byte, n int) []byte
+	appendArrayHeader(buf []byte, n int) []byte
+	appendString(buf []byte, value string) []byte
+	appendTime(buf []byte, t time.Time) []byte
+	appendField(buf []byte, field Field) []byte
+}
+
+type msgpackFormat struct{}
+
+func (msgpackFormat) appendMapHeader(buf []byte, n int) []byte { return appendMsgpackMapHeader(buf, n) }
+func (msgpackFormat) appendArrayHeader(buf []byte, n int) []byte {
+	return appendMsgpackArrayHeader(buf, n)
+}
+func (msgpackFormat) appendString(buf []byte, value string) []byte {
+	return appendMsgpackString(buf, value)
+}
+func (msgpackFormat) appendTime(buf []byte, t time.Time) []byte  { return appendMsgpackTime(buf, t) }
+func (msgpackFormat) appendField(buf []byte, field Field) []byte { return field.appendMsgpack(buf) }
+
+type cborFormat struct{}
+
+func (cborFormat) appendMapHeader(buf []byte, n int) []byte {
+	return appendCBORHead(buf, cborMap, uint64(n))
+}
+func (cborFormat) appendArrayHeader(buf []byte, n int) []byte {
+	return appendCBORHead(buf, cborArray, uint64(n))
+}
+func (cborFormat) appendString(buf []byte, value string) []byte { return appendCBORString(buf, value) }
+func (cborFormat) appendTime(buf []byte, t time.Time) []byte    { return appendCBORTime(buf, t) }
+func (cborFormat) appendField(buf []byte, field Field) []byte   { return field.appendCBOR(buf) }
+
+// appendBinaryEvent writes the event as a map with the entries and order of JSONEncoder.
+func appendBinaryEvent(buf []byte, format binaryFormat, keys JSONKeys, event *Event) []byte {
+	var causes []error
+	var errStack []Caller
+	if event.Err != nil {
+		if keys.ErrorCauses != "" {
+			causes = errorCauses(event.Err)
+		}
+		if keys.ErrorStack != "" {
+			errStack = errorStack(event.Err)
+		}
+	}
+	hasErr, hasCaller := event.Err != nil, event.Caller.Defined()
+	present := [...]bool{
+		keys.Timestamp != "",
+		keys.Level != "",
+		keys.Logger != "",
+		keys.Goroutine != "" && event.GoroutineId != "",
+		keys.Message != "",
+		keys.Error != "" && hasErr,
+		keys.ErrorType != "" && hasErr,
+		len(causes) > 0,
+		keys.Caller != "" && hasCaller,
+		keys.Function != "" && hasCaller,
+		len(errStack) > 0,
+		keys.Stack != "" && len(event.Stack) > 0,
+	}
+	n := len(event.Fields)
+	for _, p := range present {
+		if p {
+			n++
+		}
+	}
+	str := format.appendString
+	buf = format.appendMapHeader(buf, n)
+	if present[0] {
+		buf = format.appendTime(str(buf, keys.Timestamp), event.Timestamp)
+	}
+	if present[1] {
+		buf = str(str(buf, keys.Level), event.Level.Long())
+	}
+	if present[2] {
+		buf = str(str(buf, keys.Logger), event.Logger)
+	}
+	if present[3] {
+		buf = str(str(buf, keys.Goroutine), event.GoroutineId)
+	}
+	if present[4] {
+		buf = str(str(buf, keys.Message), event.Message)
+	}
+	if present[5] {
+		buf = str(str(buf, keys.Error), event.Err.Error())
+	}
+	if present[6] {
+		buf = str(str(buf, keys.ErrorType), errorType(event.Err))
+	}
+	if present[7] {
+		buf = format.appendArrayHeader(str(buf, keys.ErrorCauses), len(causes))
+		for _, cause := range causes {
+			buf = format.appendMapHeader(buf, 2)
+			buf = str(str(buf, "type"), errorType(cause))
+			buf = str(str(buf, "message"), cause.Error())
+		}
+	}
+	if present[8] {
+		buf = str(str(buf, keys.Caller), event.Caller.String())
+	}
+	if present[9] {
+		buf = str(str(buf, keys.Function), event.Caller.Function)
+	}
+	if present[10] {
+		buf = appendBinaryStack(str(buf, keys.ErrorStack), format, errStack)
+	}
+	if present[11] {
+		buf = appendBinaryStack(str(buf, keys.Stack), format, event.Stack)
+	}
+	for _, field := range event.Fields {
+		buf = format.appendField(str(buf, field.Key), field)
+	}
+	return buf
+}
+
+func appendBinaryStack(buf []byte, format binaryFormat, stack []Caller) []byte {
+	buf = format.appendArrayHeader(buf, len(stack))
+	for _, frame := range stack {
+		buf = format.appendString(buf, frame.Function+" ("+frame.File+":"+strconv.Itoa(frame.Line)+")")
+	}
+	return buf
+}
+
+// MsgpackDecoder reads events written by MsgpackEncoder, e.g. to replay recorded events into another
+// logger. The error of a decoded event only carries the message.
+type MsgpackDecoder struct {
+	reader *bufio.Reader
+	Keys   JSONKeys
+}
+
+func NewMsgpackDecoder(r io.Reader) *MsgpackDecoder {
+	return &MsgpackDecoder{reader: bufio.NewReader(r), Keys: DefaultJSONKeys}
+}
+
+// Decode reads the next event. It returns io.EOF at the end of the input.
+func (decoder *MsgpackDecoder) Decode(event *Event) error {
+	value, err := readMsgpackValue(decoder.reader, "", 0)
+	return decodeBinaryEvent(decoder.Keys, event, value, err)
+}
+
+// CBORDecoder reads events written by CBOREncoder. The error of a decoded event only carries the
+// message.
+type CBORDecoder struct {
+	reader *bufio.Reader
+	Keys   JSONKeys
+}
+
+func NewCBORDecoder(r io.Reader) *CBORDecoder {
+	return &CBORDecoder{reader: bufio.NewReader(r), Keys: DefaultJSONKeys}
+}
+
+// Decode reads the next event. It returns io.EOF at the end of the input.
+func (decoder *CBORDecoder) Decode(event *Event) error {
+	value, err := readCBORValue(decoder.reader, "", 0)
+	return decodeBinaryEvent(decoder.Keys, event, value, err)
+}
+
+var errDecodeNoMap = errors.New("go_logger: decoded event is no map")
+
+func decodeBinaryEvent(keys JSONKeys, event *Event, value Field, err error) error {
+	if err != nil {
+		return err
+	}
+	if value.Type != ObjectType {
+		return errDecodeNoMap
+	}
+	entries, _ := value.Interface.([]Field)
+	*event = eventFromFields(keys, entries)
+	return nil
+}
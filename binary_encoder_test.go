@@ -0,0 +1,53 @@
+package go_logger_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	golden := logtest.GoldenEvents()
+	// without the event repeating the error key and an Any field, which do not survive a round trip
+	events := append(golden[:4:4], golden[5:]...)
+	events = append(events,
+		&golog.Event{Timestamp: golden[4].Timestamp, Level: golog.ERROR, Message: "failed", Err: errors.New("connection refused")},
+		&golog.Event{Timestamp: time.Date(3000, 1, 1, 0, 0, 0, 1, time.UTC), Level: golog.INFO, Message: "far future",
+			Fields: []golog.Field{golog.Time("zero", time.Time{}), golog.Int("negative", -1<<40)}},
+	)
+	tests := []struct {
+		name    string
+		encoder golog.Encoder
+		decoder func(r io.Reader) func(*golog.Event) error
+	}{
+		{"msgpack", golog.NewMsgpackEncoder(), func(r io.Reader) func(*golog.Event) error { return golog.NewMsgpackDecoder(r).Decode }},
+		{"cbor", golog.NewCBOREncoder(), func(r io.Reader) func(*golog.Event) error { return golog.NewCBORDecoder(r).Decode }},
+	}
+	json := golog.NewJSONEncoder()
+	// decoded errors only carry the message
+	json.Keys.ErrorType = ""
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decode := tt.decoder(strings.NewReader(logtest.RenderEvents(tt.encoder, events)))
+			for i, want := range events {
+				var got golog.Event
+				if err := decode(&got); err != nil {
+					t.Fatalf("event %d: %v", i, err)
+				}
+				// the JSON form covers every entry and field, independent of the decoded field types
+				if g, w := logtest.RenderEvents(json, []*golog.Event{&got}), logtest.RenderEvents(json, []*golog.Event{want}); g != w {
+					t.Errorf("event %d decoded as\n%s want\n%s", i, g, w)
+				}
+			}
+			var extra golog.Event
+			if err := decode(&extra); !errors.Is(err, io.EOF) {
+				t.Errorf("decoding past the end returned %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package go_logger
+
+import (
+	"encoding/base64"
+	"errors"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BinaryMode selects how messages and field values with unprintable data are written, e.g. raw
+// protocol payloads whose control sequences would corrupt a terminal or break line-oriented parsers.
+type BinaryMode uint8
+
+const (
+	// BinaryRaw writes values unchanged.
+	BinaryRaw BinaryMode = iota
+	// BinaryStrip removes control characters and invalid UTF-8.
+	BinaryStrip
+	// BinaryHex replaces values containing unprintable data by "hex:" and their hex encoding.
+	BinaryHex
+	// BinaryBase64 replaces values containing unprintable data by "base64:" and their standard base64 encoding.
+	BinaryBase64
+)
+
+// BinarySafe sets how messages, errors and field values with control characters or invalid UTF-8 are
+// written. Tabs count as printable, as do newlines in messages, which are handled as set by Multiline.
+func (logger *Logger) BinarySafe(mode BinaryMode) *Logger {
+	logger = logger.derive()
+	logger.binaryMode = mode
+	return logger
+}
+
+func binarySafeEvent(event *Event, mode BinaryMode) {
+	message, _ := binarySafeValue(event.Message, mode, true)
+	event.Message = message
+	if event.Err != nil {
+		if text, changed := binarySafeValue(event.Err.Error(), mode, false); changed {
+			event.Err = errors.New(text)
+		}
+	}
+	safe := func(value string) (string, bool) { return binarySafeValue(value, mode, false) }
+	if fields, changed := transformFields(event.Fields, safe); changed {
+		event.Fields = fields
+	}
+}
+
+func binarySafeValue(value string, mode BinaryMode, newlines bool) (string, bool) {
+	i := 0
+	for i < len(value) {
+		ok, size := printableRune(value[i:], newlines)
+		if !ok {
+			break
+		}
+		i += size
+	}
+	if i == len(value) {
+		return value, false
+	}
+	switch mode {
+	case BinaryStrip:
+		sb := buffer{}
+		sb.Grow(len(value))
+		sb.WriteString(value[:i])
+		for i < len(value) {
+			ok, size := printableRune(value[i:], newlines)
+			if ok {
+				sb.WriteString(value[i : i+size])
+			}
+			i += size
+		}
+		return sb.String(), true
+	case BinaryHex:
+		text := make([]byte, 0, len("hex:")+2*len(value))
+		text = append(text, "hex:"...)
+		for j := 0; j < len(value); j++ {
+			text = append(text, hex[value[j]>>4], hex[value[j]&0xf])
+		}
+		return string(text), true
+	case BinaryBase64:
+		return "base64:" + base64.StdEncoding.EncodeToString([]byte(value)), true
+	}
+	return value, false
+}
+
+// printableRune reports whether the rune at the start of s is valid UTF-8 and printable, and returns its size.
+func printableRune(s string, newlines bool) (bool, int) {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError && size == 1 {
+		return false, 1
+	}
+	return !unicode.IsControl(r) || r == '\t' || newlines && r == '\n', size
+}
@@ -0,0 +1,38 @@
+package go_logger_test
+
+import (
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestBinarySafe(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        golog.BinaryMode
+		message     string
+		value       string
+		wantMessage string
+		wantValue   string
+	}{
+		{name: "printable", mode: golog.BinaryHex, message: "tab\tand\nnewline", value: "ok\t", wantMessage: "tab\tand\nnewline", wantValue: "ok\t"},
+		{name: "raw", mode: golog.BinaryRaw, message: "a\x1b[2Jb", value: "\xff", wantMessage: "a\x1b[2Jb", wantValue: "\xff"},
+		{name: "strip", mode: golog.BinaryStrip, message: "a\x1b[2Jb", value: "x\x00y\xffz\n", wantMessage: "a[2Jb", wantValue: "xyz"},
+		{name: "hex", mode: golog.BinaryHex, message: "a\x00", value: "\x01\xab", wantMessage: "hex:6100", wantValue: "hex:01ab"},
+		{name: "base64", mode: golog.BinaryBase64, message: "ok", value: "\x00\x01\x02", wantMessage: "ok", wantValue: "base64:AAEC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, observer := logtest.NewObservedLogger(golog.INFO)
+			logger.Multiline(golog.MultilineKeep).BinarySafe(tt.mode).With(golog.String("payload", tt.value)).Info(tt.message)
+			event := observer.All()[0]
+			if event.Message != tt.wantMessage {
+				t.Errorf("message %q, want %q", event.Message, tt.wantMessage)
+			}
+			if event.Fields[0].String != tt.wantValue {
+				t.Errorf("payload %q, want %q", event.Fields[0].String, tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package go_logger
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// buffer is the append-only byte slice the built-in encoders write into. Buffers are pooled, so
+// encoding an event into one does not allocate once the pool is warm.
+type buffer []byte
+
+// maxPooledBuffer keeps the occasional huge event from pinning its buffer in the pool.
+const maxPooledBuffer = 64 << 10
+
+var bufferPool = sync.Pool{New: func() any {
+	buf := make(buffer, 0, 256)
+	return &buf
+}}
+
+func getBuffer() *buffer {
+	buf := bufferPool.Get().(*buffer)
+	*buf = (*buf)[:0]
+	return buf
+}
+
+func putBuffer(buf *buffer) {
+	if cap(*buf) > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+func (buf *buffer) Write(p []byte) (int, error) {
+	*buf = append(*buf, p...)
+	return len(p), nil
+}
+
+func (buf *buffer) WriteString(s string) (int, error) {
+	*buf = append(*buf, s...)
+	return len(s), nil
+}
+
+func (buf *buffer) WriteByte(c byte) error {
+	*buf = append(*buf, c)
+	return nil
+}
+
+func (buf *buffer) WriteRune(r rune) (int, error) {
+	n := len(*buf)
+	*buf = utf8.AppendRune(*buf, r)
+	return len(*buf) - n, nil
+}
+
+func (buf *buffer) Grow(n int) {
+	if cap(*buf)-len(*buf) < n {
+		grown := make(buffer, len(*buf), 2*cap(*buf)+n)
+		copy(grown, *buf)
+		*buf = grown
+	}
+}
+
+func (buf *buffer) Len() int {
+	return len(*buf)
+}
+
+func (buf *buffer) Reset() {
+	*buf = (*buf)[:0]
+}
+
+func (buf *buffer) String() string {
+	return string(*buf)
+}
+
+// bufferEncoder is implemented by the built-in encoders, which write into a pooled buffer instead of
+// the strings.Builder of the Encoder interface.
+type bufferEncoder interface {
+	encode(buf *buffer, event *Event)
+}
+
+// encodeTo implements Encoder.Encode for a built-in encoder.
+func encodeTo(sb *strings.Builder, encoder bufferEncoder, event *Event) {
+	buf := getBuffer()
+	encoder.encode(buf, event)
+	sb.Write(*buf)
+	putBuffer(buf)
+}
+
+// encodeEvent encodes event into a pooled buffer, which the caller returns with putBuffer. Custom
+// encoders go through a strings.Builder.
+func encodeEvent(encoder Encoder, event *Event) *buffer {
+	buf := getBuffer()
+	if encoder, ok := encoder.(bufferEncoder); ok {
+		encoder.encode(buf, event)
+		return buf
+	}
+	sb := strings.Builder{}
+	encoder.Encode(&sb, event)
+	buf.WriteString(sb.String())
+	return buf
+}
@@ -0,0 +1,120 @@
+package go_logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EventBuilder collects fields for a single event. Builders are pooled and must not be used
+// after Msg, Msgf or Send. A nil builder is returned for disabled levels; all of its methods are no-ops.
+type EventBuilder struct {
+	logger *Logger
+	level  Level
+	err    error
+	fields []Field
+}
+
+var eventBuilderPool = sync.Pool{
+	New: func() any { return &EventBuilder{fields: make([]Field, 0, 16)} },
+}
+
+func (logger *Logger) TraceEvent() *EventBuilder { return logger.newEvent(TRACE) }
+func (logger *Logger) DebugEvent() *EventBuilder { return logger.newEvent(DEBUG) }
+func (logger *Logger) InfoEvent() *EventBuilder  { return logger.newEvent(INFO) }
+func (logger *Logger) WarnEvent() *EventBuilder  { return logger.newEvent(WARN) }
+func (logger *Logger) ErrorEvent() *EventBuilder { return logger.newEvent(ERROR) }
+func (logger *Logger) FatalEvent() *EventBuilder { return logger.newEvent(FATAL) }
+
+func (logger *Logger) newEvent(level Level) *EventBuilder {
+	if !logger.enabled(level) {
+		return nil
+	}
+	builder := eventBuilderPool.Get().(*EventBuilder)
+	builder.logger = logger
+	builder.level = level
+	builder.err = nil
+	builder.fields = append(builder.fields[:0], logger.fields...)
+	return builder
+}
+
+func (builder *EventBuilder) Field(field Field) *EventBuilder {
+	if builder != nil {
+		builder.fields = append(builder.fields, field)
+	}
+	return builder
+}
+func (builder *EventBuilder) Str(key string, value string) *EventBuilder {
+	return builder.Field(String(key, value))
+}
+func (builder *EventBuilder) Int(key string, value int) *EventBuilder {
+	return builder.Field(Int(key, value))
+}
+func (builder *EventBuilder) Int64(key string, value int64) *EventBuilder {
+	return builder.Field(Int64(key, value))
+}
+func (builder *EventBuilder) Uint64(key string, value uint64) *EventBuilder {
+	return builder.Field(Uint64(key, value))
+}
+func (builder *EventBuilder) Float64(key string, value float64) *EventBuilder {
+	return builder.Field(Float64(key, value))
+}
+func (builder *EventBuilder) Bool(key string, value bool) *EventBuilder {
+	return builder.Field(Bool(key, value))
+}
+func (builder *EventBuilder) Dur(key string, value time.Duration) *EventBuilder {
+	return builder.Field(Duration(key, value))
+}
+func (builder *EventBuilder) HumanDur(key string, value time.Duration) *EventBuilder {
+	return builder.Field(HumanDuration(key, value))
+}
+func (builder *EventBuilder) Bytes(key string, value int64) *EventBuilder {
+	return builder.Field(Bytes(key, value))
+}
+func (builder *EventBuilder) Count(key string, value int64) *EventBuilder {
+	return builder.Field(Count(key, value))
+}
+func (builder *EventBuilder) Time(key string, value time.Time) *EventBuilder {
+	return builder.Field(Time(key, value))
+}
+func (builder *EventBuilder) Any(key string, value any) *EventBuilder {
+	if builder == nil {
+		return nil
+	}
+	return builder.Field(Any(key, value))
+}
+
+// Err sets the error of the event, rendered the same way as for the *Err logging methods.
+func (builder *EventBuilder) Err(err error) *EventBuilder {
+	if builder != nil {
+		builder.err = err
+	}
+	return builder
+}
+
+func (builder *EventBuilder) Msg(msg string) {
+	if builder == nil {
+		return
+	}
+	event := createEvent(builder.level, msg, builder.err)
+	event.Fields = builder.fields
+	builder.logger.log(event)
+	builder.release()
+}
+func (builder *EventBuilder) Msgf(format string, args ...any) {
+	if builder == nil {
+		return
+	}
+	builder.Msg(fmt.Sprintf(format, args...))
+}
+func (builder *EventBuilder) Send() { builder.Msg("") }
+
+func (builder *EventBuilder) release() {
+	builder.logger = nil
+	builder.err = nil
+	for i := range builder.fields {
+		builder.fields[i] = Field{}
+	}
+	builder.fields = builder.fields[:0]
+	eventBuilderPool.Put(builder)
+}
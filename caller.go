@@ -0,0 +1,124 @@
+package go_logger
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const packagePath = "github.com/jeschu/go-logger"
+
+// Caller is the source location an event was logged from. The zero value means no caller was captured.
+type Caller struct {
+	File     string
+	Line     int
+	Function string
+}
+
+func (caller Caller) Defined() bool { return caller.File != "" }
+
+// ShortFile returns the file name together with its directory, e.g. "server/handler.go".
+func (caller Caller) ShortFile() string {
+	idx := strings.LastIndexByte(caller.File, '/')
+	if idx < 0 {
+		return caller.File
+	}
+	if prev := strings.LastIndexByte(caller.File[:idx], '/'); prev >= 0 {
+		return caller.File[prev+1:]
+	}
+	return caller.File
+}
+
+func (caller Caller) String() string {
+	return caller.ShortFile() + ":" + strconv.Itoa(caller.Line)
+}
+
+// WithCaller enables capturing the file, line and function of the logging call.
+func (logger *Logger) WithCaller(caller bool) *Logger {
+	logger = logger.derive()
+	logger.caller = caller
+	return logger
+}
+
+// CallerSkip skips additional stack frames outside this package, for use by wrapper packages.
+func (logger *Logger) CallerSkip(skip int) *Logger {
+	logger = logger.derive()
+	logger.callerSkip = skip
+	return logger
+}
+
+func captureCaller(skip int) Caller {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !loggerFrame(frame.Function) {
+			if skip <= 0 {
+				return Caller{File: frame.File, Line: frame.Line, Function: frame.Function}
+			}
+			skip--
+		}
+		if !more {
+			return Caller{}
+		}
+	}
+}
+
+// StackTraceAt attaches the stack of the logging goroutine to all events at or above level.
+func (logger *Logger) StackTraceAt(level Level) *Logger {
+	logger = logger.derive()
+	logger.stackTraceLevel = level
+	return logger
+}
+
+func captureStack() []Caller {
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	return stackOf(pcs[:n])
+}
+
+// loggerFrame reports whether function belongs to this package or one of its subpackages, such as the
+// gorm and gRPC adapters, which are never the caller of an event. Tests of the subpackages are callers.
+func loggerFrame(function string) bool {
+	if strings.HasPrefix(function, packagePath+".") {
+		return true
+	}
+	sub, ok := strings.CutPrefix(function, packagePath+"/")
+	if !ok {
+		return false
+	}
+	pkg, _, _ := strings.Cut(sub[strings.LastIndexByte(sub, '/')+1:], ".")
+	return !strings.HasSuffix(pkg, "_test")
+}
+
+// stackOf resolves program counters as returned by runtime.Callers, leaving out frames of this package.
+func stackOf(pcs []uintptr) []Caller {
+	frames := runtime.CallersFrames(pcs)
+	var stack []Caller
+	for {
+		frame, more := frames.Next()
+		if !loggerFrame(frame.Function) {
+			stack = append(stack, Caller{File: frame.File, Line: frame.Line, Function: frame.Function})
+		}
+		if !more {
+			return stack
+		}
+	}
+}
+
+// writeStackFrames writes each frame as function and, indented once more, file and line on lines of
+// their own.
+func writeStackFrames(sb *buffer, stack []Caller, indent string) {
+	for _, frame := range stack {
+		sb.WriteByte('\n')
+		sb.WriteString(indent)
+		sb.WriteString(frame.Function)
+		sb.WriteByte('\n')
+		sb.WriteString(indent)
+		sb.WriteByte('\t')
+		sb.WriteString(frame.File)
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Itoa(frame.Line))
+	}
+}
@@ -0,0 +1,274 @@
+package go_logger
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"time"
+)
+
+// Minimal CBOR (RFC 8949) writer and reader for CBOREncoder and CBORDecoder.
+
+const (
+	cborUint   = 0 << 5
+	cborNegInt = 1 << 5
+	cborBytes  = 2 << 5
+	cborText   = 3 << 5
+	cborArray  = 4 << 5
+	cborMap    = 5 << 5
+	cborTag    = 6 << 5
+	cborSimple = 7 << 5
+)
+
+func appendCBORHead(buf []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(buf, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(buf, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, major|27), n)
+	}
+}
+
+func appendCBORInt(buf []byte, value int64) []byte {
+	if value < 0 {
+		return appendCBORHead(buf, cborNegInt, uint64(-(value + 1)))
+	}
+	return appendCBORHead(buf, cborUint, uint64(value))
+}
+
+func appendCBORString(buf []byte, value string) []byte {
+	return append(appendCBORHead(buf, cborText, uint64(len(value))), value...)
+}
+
+// appendCBORTime writes a standard date/time string (tag 0), which keeps nanoseconds and the offset.
+func appendCBORTime(buf []byte, t time.Time) []byte {
+	buf = append(buf, cborTag|0)
+	var text [64]byte
+	return appendCBORString(buf, string(t.AppendFormat(text[:0], time.RFC3339Nano)))
+}
+
+func (field Field) appendCBOR(buf []byte) []byte {
+	switch field.Type {
+	case StringType:
+		return appendCBORString(buf, field.String)
+	case IntType:
+		return appendCBORInt(buf, field.Integer)
+	case UintType:
+		return appendCBORHead(buf, cborUint, uint64(field.Integer))
+	case FloatType:
+		return binary.BigEndian.AppendUint64(append(buf, cborSimple|27), uint64(field.Integer))
+	case BoolType:
+		if field.Integer != 0 {
+			return append(buf, cborSimple|21)
+		}
+		return append(buf, cborSimple|20)
+	case DurationType:
+		return appendCBORString(buf, time.Duration(field.Integer).String())
+	case TimeType:
+		return appendCBORTime(buf, field.time())
+	case ErrorType:
+		if err, ok := field.Interface.(error); ok && err != nil {
+			return appendCBORString(buf, err.Error())
+		}
+		return append(buf, cborSimple|22)
+	case ObjectType:
+		fields, _ := field.Interface.([]Field)
+		buf = appendCBORHead(buf, cborMap, uint64(len(fields)))
+		for _, f := range fields {
+			buf = f.appendCBOR(appendCBORString(buf, f.Key))
+		}
+		return buf
+	case ArrayType:
+		fields, _ := field.Interface.([]Field)
+		buf = appendCBORHead(buf, cborArray, uint64(len(fields)))
+		for _, f := range fields {
+			buf = f.appendCBOR(buf)
+		}
+		return buf
+	default:
+		if field.Interface == nil {
+			return append(buf, cborSimple|22)
+		}
+		text := buffer{}
+		field.writeText(&text)
+		return appendCBORString(buf, text.String())
+	}
+}
+
+var errCBORUnsupported = errors.New("go_logger: unsupported cbor item")
+
+// readCBORValue reads one data item as a field with the given key. Maps become objects, arrays
+// arrays and date/time tags times; byte strings are read as strings. Indefinite lengths are not
+// supported.
+func readCBORValue(r *bufio.Reader, key string, depth int) (Field, error) {
+	head, err := r.ReadByte()
+	if err != nil {
+		if err == io.EOF && depth > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return Field{}, err
+	}
+	if depth > maxDecodeDepth {
+		return Field{}, errDecodeDepth
+	}
+	major, info := head&0xe0, head&0x1f
+	if major == cborSimple {
+		switch info {
+		case 20, 21:
+			return Bool(key, info == 21), nil
+		case 22, 23:
+			return Field{Key: key, Type: AnyType}, nil
+		case 25:
+			b, err := readDecodeBytes(r, 2)
+			if err != nil {
+				return Field{}, err
+			}
+			return Float64(key, float16ToFloat64(binary.BigEndian.Uint16(b))), nil
+		case 26:
+			b, err := readDecodeBytes(r, 4)
+			if err != nil {
+				return Field{}, err
+			}
+			return Float64(key, float64(math.Float32frombits(binary.BigEndian.Uint32(b)))), nil
+		case 27:
+			b, err := readDecodeBytes(r, 8)
+			if err != nil {
+				return Field{}, err
+			}
+			return Float64(key, math.Float64frombits(binary.BigEndian.Uint64(b))), nil
+		}
+		return Field{}, errCBORUnsupported
+	}
+	n, err := readCBORArgument(r, info)
+	if err != nil {
+		return Field{}, err
+	}
+	switch major {
+	case cborUint:
+		if n > math.MaxInt64 {
+			return Uint64(key, n), nil
+		}
+		return Int64(key, int64(n)), nil
+	case cborNegInt:
+		if n > math.MaxInt64 {
+			return Field{}, errCBORUnsupported
+		}
+		return Int64(key, -1-int64(n)), nil
+	case cborBytes, cborText:
+		b, err := readDecodeBytes(r, n)
+		if err != nil {
+			return Field{}, err
+		}
+		return String(key, string(b)), nil
+	case cborArray:
+		if n > maxDecodeLength {
+			return Field{}, errDecodeLength
+		}
+		values := make([]Field, 0, min(n, 64))
+		for i := uint64(0); i < n; i++ {
+			value, err := readCBORValue(r, "", depth+1)
+			if err != nil {
+				return Field{}, err
+			}
+			values = append(values, value)
+		}
+		return Array(key, values...), nil
+	case cborMap:
+		if n > maxDecodeLength {
+			return Field{}, errDecodeLength
+		}
+		fields := make([]Field, 0, min(n, 64))
+		for i := uint64(0); i < n; i++ {
+			k, err := readCBORValue(r, "", depth+1)
+			if err != nil {
+				return Field{}, err
+			}
+			value, err := readCBORValue(r, k.text(), depth+1)
+			if err != nil {
+				return Field{}, err
+			}
+			fields = append(fields, value)
+		}
+		return Object(key, fields...), nil
+	default:
+		value, err := readCBORValue(r, key, depth+1)
+		if err != nil {
+			return Field{}, err
+		}
+		switch {
+		case n == 0 && value.Type == StringType:
+			if t, err := time.Parse(time.RFC3339Nano, value.String); err == nil {
+				return Time(key, t), nil
+			}
+		case n == 1 && value.Type == IntType:
+			return Time(key, time.Unix(value.Integer, 0)), nil
+		case n == 1 && value.Type == FloatType:
+			seconds := math.Float64frombits(uint64(value.Integer))
+			return Time(key, time.Unix(0, int64(seconds*1e9))), nil
+		}
+		return value, nil
+	}
+}
+
+func readCBORArgument(r *bufio.Reader, info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		b, err := readDecodeBytes(r, 1)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(b[0]), nil
+	case info == 25:
+		b, err := readDecodeBytes(r, 2)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case info == 26:
+		b, err := readDecodeBytes(r, 4)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	case info == 27:
+		b, err := readDecodeBytes(r, 8)
+		if err != nil {
+			return 0, err
+		}
+		return binary.BigEndian.Uint64(b), nil
+	default:
+		return 0, errCBORUnsupported
+	}
+}
+
+func float16ToFloat64(bits uint16) float64 {
+	exponent := int(bits>>10) & 0x1f
+	mantissa := float64(bits & 0x3ff)
+	var value float64
+	switch exponent {
+	case 0:
+		value = math.Ldexp(mantissa, -24)
+	case 0x1f:
+		if mantissa == 0 {
+			value = math.Inf(1)
+		} else {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mantissa+1024, exponent-25)
+	}
+	if bits&0x8000 != 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,256 @@
+package go_logger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"sync"
+)
+
+// A hash chain links lines: each line carries the hash of its predecessor as prevHash and ends with
+// its own hash, the SHA-256, or HMAC-SHA256 if a key is given, of the predecessor's hash, a newline
+// and the line up to the hash. JSON objects get the entries "prevHash" and "hash", other lines
+// " prevHash=" and " hash=". Changing, removing or reordering lines breaks the chain at that point;
+// with a key, a forger cannot recompute the chain either.
+type hashChain struct {
+	prev string
+	key  []byte
+}
+
+const (
+	jsonHashSuffix = `,"hash":"`
+	textHashSuffix = ` hash=`
+)
+
+// genesisHash is the predecessor of the first line of a chain.
+const genesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+func newHashChain(key []byte) *hashChain {
+	return &hashChain{prev: genesisHash, key: key}
+}
+
+// sealLine returns line, without its newline, with prevHash and hash appended.
+func (chain *hashChain) sealLine(line []byte) []byte {
+	line = bytes.TrimSuffix(line, []byte{'\n'})
+	var body []byte
+	object := len(line) >= 2 && line[0] == '{' && line[len(line)-1] == '}'
+	if object {
+		body = append(body, line[:len(line)-1]...)
+		if len(bytes.TrimSpace(body)) > 1 {
+			body = append(body, ',')
+		}
+		body = append(body, `"prevHash":"`+chain.prev+`"`...)
+		body = append(body, jsonHashSuffix...)
+	} else {
+		body = append(body, line...)
+		body = append(body, " prevHash="+chain.prev+textHashSuffix...)
+	}
+	suffix := len(textHashSuffix)
+	if object {
+		suffix = len(jsonHashSuffix)
+	}
+	sum := chainHash(chain.key, chain.prev, body[:len(body)-suffix])
+	chain.prev = sum
+	body = append(body, sum...)
+	if object {
+		body = append(body, "\"}"...)
+	}
+	return append(body, '\n')
+}
+
+func chainHash(key []byte, prev string, body []byte) string {
+	var h hash.Hash
+	if key != nil {
+		h = hmac.New(sha256.New, key)
+	} else {
+		h = sha256.New()
+	}
+	h.Write([]byte(prev))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	sum := h.Sum(nil)
+	text := make([]byte, 2*len(sum))
+	for i, b := range sum {
+		text[2*i], text[2*i+1] = hex[b>>4], hex[b&0xf]
+	}
+	return string(text)
+}
+
+// splitChainLine splits a sealed line into the hashed body, its prevHash and its hash.
+func splitChainLine(line []byte) (body []byte, prev, sum string, ok bool) {
+	prevKey, end := []byte(`"prevHash":"`), []byte(`"`)
+	idx := bytes.LastIndex(line, []byte(jsonHashSuffix))
+	if idx >= 0 && bytes.HasSuffix(line, []byte("\"}")) {
+		body, sum = line[:idx], string(line[idx+len(jsonHashSuffix):len(line)-2])
+	} else if idx = bytes.LastIndex(line, []byte(textHashSuffix)); idx >= 0 {
+		body, sum = line[:idx], string(line[idx+len(textHashSuffix):])
+		prevKey, end = []byte(" prevHash="), nil
+	} else {
+		return nil, "", "", false
+	}
+	if !bytes.HasSuffix(body, end) || len(body) < len(prevKey)+len(genesisHash)+len(end) {
+		return nil, "", "", false
+	}
+	start := len(body) - len(end) - len(genesisHash)
+	if !bytes.Equal(body[start-len(prevKey):start], prevKey) {
+		return nil, "", "", false
+	}
+	return body, string(body[start : start+len(genesisHash)]), sum, true
+}
+
+// ErrChainBroken is wrapped by the errors of VerifyChain for lines that were changed, removed or
+// reordered.
+var ErrChainBroken = errors.New("go_logger: hash chain broken")
+
+// VerifyChain checks the hash chain of the lines in r, written by a ChainWriter or an AuditLogger with
+// the same key, and returns an error wrapping ErrChainBroken at the first line that does not match.
+func VerifyChain(r io.Reader, key []byte) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxDecodeLength)
+	prev := genesisHash
+	for n := 1; scanner.Scan(); n++ {
+		body, linePrev, sum, ok := splitChainLine(scanner.Bytes())
+		if !ok {
+			return fmt.Errorf("%w: line %d has no hash", ErrChainBroken, n)
+		}
+		if linePrev != prev || !hmac.Equal([]byte(chainHash(key, prev, body)), []byte(sum)) {
+			return fmt.Errorf("%w: line %d", ErrChainBroken, n)
+		}
+		prev = sum
+	}
+	return scanner.Err()
+}
+
+// lastChainHash returns the hash of the last line of a chained file, reading backwards from the end.
+func lastChainHash(file *os.File) (string, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	size := info.Size()
+	for window := int64(64 * 1024); ; window *= 2 {
+		window = min(window, size)
+		buf := make([]byte, window)
+		if _, err := file.ReadAt(buf, size-window); err != nil && err != io.EOF {
+			return "", err
+		}
+		buf = bytes.TrimRight(buf, "\n")
+		start := bytes.LastIndexByte(buf, '\n')
+		if start < 0 && window < size {
+			continue
+		}
+		if len(buf) == 0 {
+			return genesisHash, nil
+		}
+		_, _, sum, ok := splitChainLine(buf[start+1:])
+		if !ok {
+			return "", fmt.Errorf("%w: last line has no hash", ErrChainBroken)
+		}
+		return sum, nil
+	}
+}
+
+// ChainWriter adds a hash chain to every line written through it, see VerifyChain. Lines may be JSON
+// objects or text; partial lines are kept until their newline arrives.
+type ChainWriter struct {
+	mutex   sync.Mutex
+	out     io.Writer
+	chain   *hashChain
+	partial []byte
+}
+
+// NewChainWriter starts a new chain on out. A nil key chains plain SHA-256 hashes, which detect
+// accidental changes; a secret key makes the chain an HMAC that cannot be forged without the key.
+func NewChainWriter(out io.Writer, key []byte) *ChainWriter {
+	return &ChainWriter{out: out, chain: newHashChain(key)}
+}
+
+// OpenChainFile opens or creates the file at path for appending and continues its chain.
+func OpenChainFile(path string, key []byte) (*ChainWriter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := lastChainHash(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	writer := NewChainWriter(file, key)
+	writer.chain.prev = prev
+	return writer, nil
+}
+
+// Write seals the complete lines of p. If writing them fails, nothing of p is consumed and the chain
+// is left at its last written line, so p can be written again.
+func (writer *ChainWriter) Write(p []byte) (int, error) {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	prev := writer.chain.prev
+	data := p
+	if len(writer.partial) > 0 {
+		data = append(writer.partial[:len(writer.partial):len(writer.partial)], p...)
+	}
+	var sealed []byte
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		if i > 0 {
+			sealed = append(sealed, writer.chain.sealLine(data[:i])...)
+		}
+		data = data[i+1:]
+	}
+	if err := writeFull(writer.out, string(sealed)); err != nil {
+		writer.chain.prev = prev
+		return 0, err
+	}
+	writer.partial = append([]byte(nil), data...)
+	return len(p), nil
+}
+
+// Sync passes on to the underlying writer.
+func (writer *ChainWriter) Sync() error {
+	if out, ok := writer.out.(interface{ Sync() error }); ok {
+		return out.Sync()
+	}
+	return nil
+}
+
+// Close writes a remaining partial line and closes the underlying writer if it is an io.Closer.
+// os.Stdout and os.Stderr are never closed.
+func (writer *ChainWriter) Close() error {
+	writer.mutex.Lock()
+	var err error
+	if len(writer.partial) > 0 {
+		err = writeFull(writer.out, string(writer.chain.sealLine(writer.partial)))
+		writer.partial = nil
+	}
+	writer.mutex.Unlock()
+	if out, ok := writer.out.(io.Closer); ok && out != os.Stdout && out != os.Stderr {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// HashChain makes the logger write its current output through a ChainWriter with key, so call it
+// after Out. Sinks are chained by writing to a ChainWriter.
+//
+// The chain starts at the genesis hash, so the output must not hold a chain already; continue one with
+// OpenChainFile and Out instead. Only the returned logger and its children write through the chain:
+// lines the original logger still writes to the same output break it.
+func (logger *Logger) HashChain(key []byte) *Logger {
+	logger = logger.derive()
+	logger.followsConfig = false
+	logger.out = NewChainWriter(logger.out, key)
+	return logger
+}
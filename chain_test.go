@@ -0,0 +1,160 @@
+package go_logger_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// failingWriter fails the next write once fail is set.
+type failingWriter struct {
+	buf  bytes.Buffer
+	fail bool
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.fail {
+		w.fail = false
+		return 0, errors.New("disk full")
+	}
+	return w.buf.Write(p)
+}
+
+func TestChainWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    []byte
+		writes []string
+		tamper func(out string) string
+		verify []byte
+		broken bool
+	}{
+		{name: "text lines", writes: []string{"first\nsecond\n"}},
+		{name: "json lines", writes: []string{`{"message":"first"}` + "\n", `{}` + "\n"}},
+		{name: "partial lines", writes: []string{"fir", "st\nsec", "ond\n"}},
+		{name: "hmac", key: []byte("secret"), writes: []string{"first\nsecond\n"}, verify: []byte("secret")},
+		{name: "wrong key", key: []byte("secret"), writes: []string{"first\n"}, verify: []byte("guess"), broken: true},
+		{
+			name:   "changed line",
+			writes: []string{"first\nsecond\n"},
+			tamper: func(out string) string { return strings.Replace(out, "second", "Second", 1) },
+			broken: true,
+		},
+		{
+			name:   "removed line",
+			writes: []string{"first\nsecond\nthird\n"},
+			tamper: func(out string) string {
+				lines := strings.SplitAfter(out, "\n")
+				return lines[0] + lines[2]
+			},
+			broken: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := bytes.Buffer{}
+			writer := golog.NewChainWriter(&out, tt.key)
+			for _, w := range tt.writes {
+				if n, err := writer.Write([]byte(w)); err != nil || n != len(w) {
+					t.Fatalf("Write returned %d, %v", n, err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+			chained := out.String()
+			if tt.tamper != nil {
+				chained = tt.tamper(chained)
+			}
+			err := golog.VerifyChain(strings.NewReader(chained), tt.verify)
+			if tt.broken != errors.Is(err, golog.ErrChainBroken) || !tt.broken && err != nil {
+				t.Errorf("VerifyChain returned %v\n%s", err, chained)
+			}
+		})
+	}
+}
+
+func TestChainWriterFailedWrite(t *testing.T) {
+	out := &failingWriter{}
+	writer := golog.NewChainWriter(out, nil)
+	if _, err := writer.Write([]byte("first\nsec")); err != nil {
+		t.Fatal(err)
+	}
+	out.fail = true
+	if n, err := writer.Write([]byte("ond\n")); err == nil || n != 0 {
+		t.Fatalf("failed Write returned %d, %v", n, err)
+	}
+	if _, err := writer.Write([]byte("ond\nthird\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := golog.VerifyChain(&out.buf, nil); err != nil {
+		t.Errorf("chain broken after retried write: %v", err)
+	}
+}
+
+func TestOpenChainFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chain.log")
+	for _, line := range []string{"first\n", "second\n"} {
+		writer, err := golog.OpenChainFile(path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := golog.VerifyChain(file, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAuditLogger(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   string
+		outcome string
+		fail    bool
+		wantErr bool
+	}{
+		{name: "success", actor: "alice", outcome: golog.AuditSuccess},
+		{name: "denied", actor: "bob", outcome: golog.AuditDenied},
+		{name: "incomplete", outcome: golog.AuditFailure, wantErr: true},
+		{name: "failed write", actor: "carol", outcome: golog.AuditSuccess, fail: true, wantErr: true},
+	}
+	out := &failingWriter{}
+	audit := golog.NewAuditLogger(out)
+	logged := 0
+	for _, tt := range tests {
+		out.fail = tt.fail
+		err := audit.Log(tt.actor, "delete", "user/42", tt.outcome, golog.String("reason", tt.name))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Log returned %v", tt.name, err)
+		}
+		if err == nil {
+			logged++
+		}
+	}
+	if err := audit.Log("dave", "delete", "user/42", golog.AuditSuccess); err != nil {
+		t.Fatal(err)
+	}
+	logged++
+	if lines := strings.Count(out.buf.String(), "\n"); lines != logged {
+		t.Errorf("%d lines, want %d", lines, logged)
+	}
+	if err := golog.VerifyAuditLog(&out.buf); err != nil {
+		t.Errorf("audit log broken: %v\n%s", err, out.buf.String())
+	}
+}
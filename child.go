@@ -0,0 +1,32 @@
+package go_logger
+
+// With returns a child logger which shares the configuration of its parent and adds the given fields to every event.
+func (logger *Logger) With(fields ...Field) *Logger {
+	child := *logger
+	child.fields = make([]Field, 0, len(logger.fields)+len(fields))
+	child.fields = append(child.fields, logger.fields...)
+	child.fields = append(child.fields, fields...)
+	return &child
+}
+
+// WithError returns a child logger which attaches err to every event not carrying an error of its own.
+// For a single event use the Err field or the *Err methods.
+func (logger *Logger) WithError(err error) *Logger {
+	child := *logger
+	child.err = err
+	return &child
+}
+
+// Named returns a child logger whose name is the parent's name extended by "." and the given segment.
+// The child starts with the level of its parent but can be changed independently.
+func (logger *Logger) Named(name string) *Logger {
+	child := *logger
+	child.level = newLevel(logger.GetLevel())
+	if logger.name == "" {
+		child.name = name
+	} else if name != "" {
+		child.name = logger.name + "." + name
+	}
+	child.stats = statsFor(child.name)
+	return &child
+}
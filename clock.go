@@ -0,0 +1,59 @@
+package go_logger
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the timestamps of events.
+type Clock interface {
+	Now() time.Time
+}
+
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time { return f() }
+
+// SystemClock returns time.Now.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// Clock sets the clock taking the timestamps of events, e.g. a fixed clock for golden files or the
+// virtual time of a simulation. Children inherit the clock.
+func (logger *Logger) Clock(clock Clock) *Logger {
+	logger = logger.derive()
+	logger.clock = clock
+	return logger
+}
+
+// FixedClock returns a clock always returning t.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// ManualClock is a clock only advancing when told to.
+type ManualClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+func (clock *ManualClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	return clock.now
+}
+
+func (clock *ManualClock) Advance(d time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	clock.now = clock.now.Add(d)
+}
+
+func (clock *ManualClock) Set(t time.Time) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	clock.now = t
+}
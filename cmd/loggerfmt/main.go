@@ -0,0 +1,145 @@
+// Command loggerfmt renders the JSON or PLAIN output of go_logger for humans, e.g.
+//
+//	kubectl logs -f app | loggerfmt -level WARN -fields request_id,status
+//
+// It reads the files given as arguments, or standard input, and writes the PLAIN or PRETTY layout,
+// colored when writing to a terminal. Lines that are no events are passed through unchanged.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	golog "github.com/jeschu/go-logger"
+	"golang.org/x/term"
+)
+
+func main() {
+	input := flag.String("input", "json", "format of the input: json or plain")
+	output := flag.String("output", "plain", "layout of the output: plain or pretty")
+	level := flag.String("level", "TRACE", "minimum level of the events shown")
+	fields := flag.String("fields", "", "comma separated keys of the fields shown; all fields if empty")
+	color := flag.String("color", "auto", "colored output: auto, always or never")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [file ...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	formatter, err := newFormatter(*input, *output, *level, *fields, *color)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "loggerfmt:", err)
+		os.Exit(2)
+	}
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	status := 0
+	for _, name := range files {
+		if err := formatter.formatFile(name, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, "loggerfmt:", err)
+			status = 1
+		}
+	}
+	os.Exit(status)
+}
+
+type formatter struct {
+	input   golog.Format
+	encoder golog.Encoder
+	level   golog.Level
+	fields  map[string]bool
+}
+
+func newFormatter(input, output, level, fields, color string) (*formatter, error) {
+	inputFormat, err := golog.ParseFormat(input)
+	if err != nil {
+		return nil, err
+	}
+	minLevel, err := golog.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	var colorized bool
+	switch color {
+	case "auto":
+		colorized = os.Getenv("NO_COLOR") == "" && term.IsTerminal(int(os.Stdout.Fd()))
+	case "always":
+		colorized = true
+	case "never":
+	default:
+		return nil, fmt.Errorf("unknown color mode %q", color)
+	}
+	f := &formatter{input: inputFormat, level: minLevel}
+	switch outputFormat, err := golog.ParseFormat(output); {
+	case err != nil:
+		return nil, err
+	case outputFormat == golog.PRETTY:
+		f.encoder = golog.NewPrettyEncoder(colorized)
+	case outputFormat == golog.PLAIN:
+		f.encoder = golog.NewPlainEncoder(colorized)
+	default:
+		return nil, fmt.Errorf("unsupported output layout %q", output)
+	}
+	if fields != "" {
+		f.fields = map[string]bool{}
+		for _, key := range strings.Split(fields, ",") {
+			f.fields[strings.TrimSpace(key)] = true
+		}
+	}
+	return f, nil
+}
+
+func (f *formatter) formatFile(name string, out io.Writer) error {
+	in := io.Reader(os.Stdin)
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	reader := golog.NewEventReader(in, f.input)
+	sb := strings.Builder{}
+	for {
+		sb.Reset()
+		var event golog.Event
+		err := reader.Read(&event)
+		switch {
+		case errors.Is(err, golog.ErrMalformedEvent):
+			sb.WriteString(reader.Text())
+			sb.WriteByte('\n')
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		case event.Level < f.level:
+			continue
+		default:
+			f.encoder.Encode(&sb, f.selectFields(&event))
+		}
+		if _, err := io.WriteString(out, sb.String()); err != nil {
+			return err
+		}
+	}
+}
+
+func (f *formatter) selectFields(event *golog.Event) *golog.Event {
+	if f.fields == nil {
+		return event
+	}
+	selected := event.Fields[:0]
+	for _, field := range event.Fields {
+		if f.fields[field.Key] {
+			selected = append(selected, field)
+		}
+	}
+	event.Fields = selected
+	return event
+}
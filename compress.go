@@ -0,0 +1,115 @@
+package go_logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Compressor is a streaming compressor writing to an underlying writer, like *gzip.Writer or the zstd
+// encoder of github.com/klauspost/compress. Flush must write everything written so far so that it can
+// be decompressed.
+type Compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// CompressingWriter compresses everything written to it, so that long-running processes can write
+// compressed log files directly. The compressor is flushed at least every interval if anything was
+// written, which bounds the loss on a crash and lets tools like zcat read the file while it grows.
+type CompressingWriter struct {
+	mutex      sync.Mutex
+	out        io.Writer
+	compressor Compressor
+	dirty      bool
+	closed     bool
+	done       chan struct{}
+	stopped    sync.WaitGroup
+}
+
+// NewCompressingWriter compresses with compressor, which writes to out. An interval of zero only
+// flushes on Flush, Sync and Close.
+func NewCompressingWriter(out io.Writer, compressor Compressor, interval time.Duration) *CompressingWriter {
+	writer := &CompressingWriter{out: out, compressor: compressor, done: make(chan struct{})}
+	if interval > 0 {
+		writer.stopped.Add(1)
+		go func() {
+			defer writer.stopped.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-writer.done:
+					return
+				case <-ticker.C:
+					_ = writer.Flush()
+				}
+			}
+		}()
+	}
+	return writer
+}
+
+// NewGzipWriter gzips to out with the given level, e.g. gzip.DefaultCompression.
+func NewGzipWriter(out io.Writer, level int, interval time.Duration) (*CompressingWriter, error) {
+	compressor, err := gzip.NewWriterLevel(out, level)
+	if err != nil {
+		return nil, err
+	}
+	return NewCompressingWriter(out, compressor, interval), nil
+}
+
+func (writer *CompressingWriter) Write(p []byte) (int, error) {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if writer.closed {
+		return 0, ErrSinkClosed
+	}
+	writer.dirty = true
+	return writer.compressor.Write(p)
+}
+
+// Flush flushes the compressor if anything was written since the last flush.
+func (writer *CompressingWriter) Flush() error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if writer.closed || !writer.dirty {
+		return nil
+	}
+	writer.dirty = false
+	return writer.compressor.Flush()
+}
+
+// Sync flushes and syncs the underlying writer.
+func (writer *CompressingWriter) Sync() error {
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if out, ok := writer.out.(interface{ Sync() error }); ok && out != os.Stdout && out != os.Stderr {
+		return out.Sync()
+	}
+	return nil
+}
+
+// Close ends the compressed stream and closes the underlying writer if it is an io.Closer. os.Stdout
+// and os.Stderr are never closed.
+func (writer *CompressingWriter) Close() error {
+	writer.mutex.Lock()
+	if writer.closed {
+		writer.mutex.Unlock()
+		return nil
+	}
+	writer.closed = true
+	close(writer.done)
+	err := writer.compressor.Close()
+	writer.mutex.Unlock()
+	writer.stopped.Wait()
+	if out, ok := writer.out.(io.Closer); ok && out != os.Stdout && out != os.Stderr {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
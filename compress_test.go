@@ -0,0 +1,94 @@
+package go_logger_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// syncBuffer is a bytes.Buffer safe for the background flushes of CompressingWriter.
+type syncBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+// gunzipPrefix decompresses as much of a possibly unfinished gzip stream as is readable.
+func gunzipPrefix(t *testing.T, data []byte) string {
+	t.Helper()
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+	out, err := io.ReadAll(reader)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestCompressingWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		flush    func(*golog.CompressingWriter) error
+	}{
+		{name: "Flush", flush: (*golog.CompressingWriter).Flush},
+		{name: "Sync", flush: (*golog.CompressingWriter).Sync},
+		{name: "interval", interval: time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &syncBuffer{}
+			writer, err := golog.NewGzipWriter(out, gzip.BestSpeed, tt.interval)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := writer.Write([]byte("line 1\n")); err != nil {
+				t.Fatal(err)
+			}
+			if tt.flush != nil {
+				if err := tt.flush(writer); err != nil {
+					t.Fatal(err)
+				}
+			}
+			deadline := time.Now().Add(5 * time.Second)
+			for gunzipPrefix(t, out.Bytes()) != "line 1\n" {
+				if time.Now().After(deadline) {
+					t.Fatalf("readable before Close: %q", gunzipPrefix(t, out.Bytes()))
+				}
+				time.Sleep(time.Millisecond)
+			}
+			_, _ = writer.Write([]byte("line 2\n"))
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+			reader, err := gzip.NewReader(bytes.NewReader(out.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data, err := io.ReadAll(reader); err != nil || string(data) != "line 1\nline 2\n" {
+				t.Errorf("decompressed %q, %v, want both lines", data, err)
+			}
+			if _, err := writer.Write([]byte("late")); err != golog.ErrSinkClosed {
+				t.Errorf("Write after Close = %v, want ErrSinkClosed", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package go_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config describes the default logger, the level rules of the registry and the sinks. It carries
+// json, yaml and toml tags so that the unmarshal function of any of these libraries can be
+// registered with RegisterConfigFormat.
+type Config struct {
+	Level  Level            `json:"level" yaml:"level" toml:"level"`
+	Format Format           `json:"format" yaml:"format" toml:"format"`
+	Levels map[string]Level `json:"levels" yaml:"levels" toml:"levels"`
+	Sinks  []SinkConfig     `json:"sinks" yaml:"sinks" toml:"sinks"`
+}
+
+// SinkConfig describes one sink. Type is "stdout", "stderr" or "file"; Path and the rotation
+// settings only apply to files.
+type SinkConfig struct {
+	Type       string `json:"type" yaml:"type" toml:"type"`
+	Format     Format `json:"format" yaml:"format" toml:"format"`
+	Level      Level  `json:"level" yaml:"level" toml:"level"`
+	Colorized  bool   `json:"colorized" yaml:"colorized" toml:"colorized"`
+	Path       string `json:"path" yaml:"path" toml:"path"`
+	MaxSize    int64  `json:"maxSize" yaml:"maxSize" toml:"maxSize"`
+	MaxBackups int    `json:"maxBackups" yaml:"maxBackups" toml:"maxBackups"`
+	Compress   bool   `json:"compress" yaml:"compress" toml:"compress"`
+	Shared     bool   `json:"shared" yaml:"shared" toml:"shared"`
+	// MaxBackupDays and MaxTotalSize start an hourly janitor deleting older or excess rotated files.
+	MaxBackupDays int   `json:"maxBackupDays" yaml:"maxBackupDays" toml:"maxBackupDays"`
+	MaxTotalSize  int64 `json:"maxTotalSize" yaml:"maxTotalSize" toml:"maxTotalSize"`
+}
+
+var configFormats = struct {
+	sync.RWMutex
+	unmarshal map[string]func([]byte, any) error
+}{unmarshal: map[string]func([]byte, any) error{".json": json.Unmarshal}}
+
+// RegisterConfigFormat registers an unmarshal function for config files with the given extension,
+// e.g. RegisterConfigFormat(".yaml", yaml.Unmarshal). JSON is supported out of the box.
+func RegisterConfigFormat(ext string, unmarshal func([]byte, any) error) {
+	configFormats.Lock()
+	configFormats.unmarshal[strings.ToLower(ext)] = unmarshal
+	configFormats.Unlock()
+}
+
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	configFormats.RLock()
+	unmarshal, ok := configFormats.unmarshal[ext]
+	configFormats.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("go_logger: no config format registered for %q", ext)
+	}
+	config := &Config{Level: WARN}
+	if err := unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("go_logger: parsing %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// configOutput is the format and the sinks set by ApplyConfig. The default logger and the loggers
+// derived from it follow the current one until their output is set explicitly with Out, Format,
+// Encoder or Sinks. Writes are counted as active, so that a replaced output is closed only after its
+// in-flight writes have finished. Unlike a lock, the count allows sinks and error handlers to log again.
+type configOutput struct {
+	format Format
+	sinks  []Sink
+	active atomic.Int64
+	closed atomic.Bool
+}
+
+var appliedConfig atomic.Pointer[configOutput]
+
+// configuredOutput returns the applied output, to be released after use, if the logger follows the
+// config, else nil.
+func (logger *Logger) configuredOutput() *configOutput {
+	if !logger.followsConfig {
+		return nil
+	}
+	for {
+		output := appliedConfig.Load()
+		if output == nil {
+			return nil
+		}
+		output.active.Add(1)
+		if !output.closed.Load() {
+			return output
+		}
+		output.active.Add(-1)
+	}
+}
+
+func (output *configOutput) release() {
+	output.active.Add(-1)
+}
+
+// close waits for the active writes and closes the sinks. The output must not be current anymore.
+func (output *configOutput) close() {
+	output.closed.Store(true)
+	for output.active.Load() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	for _, sink := range output.sinks {
+		_ = sink.Close()
+	}
+}
+
+// followConfig returns the logger itself if it follows the config, else a copy that does.
+func (logger *Logger) followConfig() *Logger {
+	if logger.followsConfig {
+		return logger
+	}
+	logger = logger.derive()
+	logger.followsConfig = true
+	return logger
+}
+
+// ApplyConfig configures the default logger and all registered loggers. Loggers that got their own
+// output are replaced in the registry by copies following the config. Only the replacements follow it:
+// a *Logger obtained before, e.g. stored in a package variable, keeps writing to its own output,
+// although its level is updated. Fetch loggers from the registry after ApplyConfig, or derive them
+// from loggers that follow the config, to avoid this. Sinks created by a previous ApplyConfig are
+// closed once the new ones are in place and all writes to them have finished.
+func ApplyConfig(config *Config) error {
+	sinks := make([]Sink, 0, len(config.Sinks))
+	for _, sinkConfig := range config.Sinks {
+		sink, err := sinkConfig.build()
+		if err != nil {
+			for _, s := range sinks {
+				_ = s.Close()
+			}
+			return err
+		}
+		sinks = append(sinks, sink)
+	}
+	old := appliedConfig.Swap(&configOutput{format: config.Format, sinks: sinks})
+	Default().SetLevel(config.Level)
+	SetDefault(Default().followConfig())
+	registry.mutex.Lock()
+	registry.rules = nil
+	for pattern, level := range config.Levels {
+		registry.rules = append(registry.rules, levelRule{pattern: pattern, level: level, specificity: patternSpecificity(pattern)})
+	}
+	for name, logger := range registry.loggers {
+		if level, ok := effectiveLevel(name); ok {
+			logger.SetLevel(level)
+		} else {
+			logger.SetLevel(config.Level)
+		}
+		registry.loggers[name] = logger.followConfig()
+	}
+	registry.mutex.Unlock()
+
+	if old != nil {
+		old.close()
+	}
+	return nil
+}
+
+func (config SinkConfig) build() (Sink, error) {
+	var encoder Encoder = NewPlainEncoder(config.Colorized)
+	switch config.Format {
+	case JSON:
+		encoder = NewJSONEncoder()
+	case PRETTY:
+		encoder = NewPrettyEncoder(config.Colorized)
+	}
+	switch strings.ToLower(config.Type) {
+	case "stdout":
+		return NewWriterSink(os.Stdout, encoder).Level(config.Level), nil
+	case "stderr", "":
+		return NewWriterSink(os.Stderr, encoder).Level(config.Level), nil
+	case "file":
+		sink, err := NewFileSink(config.Path, encoder)
+		if err != nil {
+			return nil, err
+		}
+		sink.Level(config.Level).MaxSize(config.MaxSize).MaxBackups(config.MaxBackups).Compress(config.Compress).Shared(config.Shared).
+			MaxBackupAge(time.Duration(config.MaxBackupDays) * 24 * time.Hour).MaxTotalSize(config.MaxTotalSize)
+		if config.MaxBackupDays > 0 || config.MaxTotalSize > 0 {
+			sink.Janitor(time.Hour)
+		}
+		return sink, nil
+	default:
+		return nil, fmt.Errorf("go_logger: unknown sink type %q", config.Type)
+	}
+}
+
+// WatchConfig loads and applies the config file and then polls it every interval, re-applying it
+// whenever its content changes. Errors during reloads are passed to onError, if set. The returned
+// function stops watching.
+func WatchConfig(path string, interval time.Duration, onError func(error)) (func(), error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadAndApply(path); err != nil {
+		return nil, err
+	}
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			current, err := os.ReadFile(path)
+			if err != nil {
+				if onError != nil {
+					onError(err)
+				}
+				continue
+			}
+			if bytes.Equal(current, data) {
+				continue
+			}
+			data = current
+			if err := loadAndApply(path); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(stop) }) }, nil
+}
+
+func loadAndApply(path string) error {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return err
+	}
+	return ApplyConfig(config)
+}
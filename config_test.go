@@ -0,0 +1,163 @@
+package go_logger_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestApplyConfig(t *testing.T) {
+	defer golog.SetDefault(golog.Default())
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		config golog.Config
+		log    func()
+		file   string
+		want   []string
+		absent []string
+	}{
+		{
+			name: "default logger writes to file sink",
+			config: golog.Config{Level: golog.INFO, Sinks: []golog.SinkConfig{
+				{Type: "file", Format: golog.JSON, Path: filepath.Join(dir, "default.log")},
+			}},
+			log:    func() { golog.Info("hello"); golog.Debug("hidden") },
+			file:   "default.log",
+			want:   []string{`"message":"hello"`},
+			absent: []string{"hidden"},
+		},
+		{
+			name: "level rules apply to registered loggers",
+			config: golog.Config{Level: golog.WARN, Levels: map[string]golog.Level{"db": golog.DEBUG}, Sinks: []golog.SinkConfig{
+				{Type: "file", Format: golog.JSON, Path: filepath.Join(dir, "rules.log")},
+			}},
+			log: func() {
+				golog.GetLogger("db.pool").Debug("db debug")
+				golog.GetLogger("http").Info("http info")
+			},
+			file:   "rules.log",
+			want:   []string{`"logger":"db.pool"`, "db debug"},
+			absent: []string{"http info"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := golog.ApplyConfig(&test.config); err != nil {
+				t.Fatal(err)
+			}
+			test.log()
+			if err := golog.Default().Flush(); err != nil {
+				t.Fatal(err)
+			}
+			data, err := os.ReadFile(filepath.Join(dir, test.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("missing %q in %s", want, data)
+				}
+			}
+			for _, absent := range test.absent {
+				if strings.Contains(string(data), absent) {
+					t.Errorf("unexpected %q in %s", absent, data)
+				}
+			}
+		})
+	}
+	if err := golog.ApplyConfig(&golog.Config{Level: golog.WARN}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestApplyConfigConcurrent reloads the config while other goroutines log; run with -race.
+func TestApplyConfigConcurrent(t *testing.T) {
+	defer golog.SetDefault(golog.Default())
+	dir := t.TempDir()
+	config := func(name string) *golog.Config {
+		return &golog.Config{Level: golog.INFO, Sinks: []golog.SinkConfig{
+			{Type: "file", Format: golog.JSON, Path: filepath.Join(dir, name)},
+		}}
+	}
+	if err := golog.ApplyConfig(config("0.log")); err != nil {
+		t.Fatal(err)
+	}
+	cached := golog.GetLogger("worker")
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				golog.Info("default")
+				cached.Info("cached")
+			}
+		}()
+	}
+	for i := 1; i <= 5; i++ {
+		time.Sleep(2 * time.Millisecond)
+		if err := golog.ApplyConfig(config(string(rune('0'+i)) + ".log")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+	if err := golog.ApplyConfig(&golog.Config{Level: golog.WARN}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i <= 5; i++ {
+		data, err := os.ReadFile(filepath.Join(dir, string(rune('0'+i))+".log"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+			if line != "" && !strings.HasSuffix(line, "}") {
+				t.Fatalf("partial line in %d.log: %q", i, line)
+			}
+		}
+	}
+}
+
+func TestWatchConfig(t *testing.T) {
+	defer golog.SetDefault(golog.Default())
+	path := filepath.Join(t.TempDir(), "logging.json")
+	if err := os.WriteFile(path, []byte(`{"level":"WARN"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stop, err := golog.WatchConfig(path, 5*time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+	if level := golog.Default().GetLevel(); level != golog.WARN {
+		t.Fatalf("level after load = %v, want WARN", level)
+	}
+	// replaced atomically, so that the watcher never reads a partial file
+	if err := os.WriteFile(path+".new", []byte(`{"level":"DEBUG"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(path+".new", path); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for golog.Default().GetLevel() != golog.DEBUG {
+		if time.Now().After(deadline) {
+			t.Fatal("config change was not applied")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	stop()
+	_ = golog.ApplyConfig(&golog.Config{Level: golog.WARN})
+}
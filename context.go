@@ -0,0 +1,146 @@
+package go_logger
+
+import (
+	"context"
+	"fmt"
+)
+
+// Keys of the correlation fields added by ContextWithRequestID, ContextWithTraceID and active spans.
+// They follow the OpenTelemetry names, which OTLPSink and DatadogEncoder map to their trace context.
+const (
+	RequestIDKey = "request_id"
+	TraceIDKey   = "trace_id"
+	SpanIDKey    = "span_id"
+)
+
+type loggerContextKey struct{}
+type fieldsContextKey struct{}
+type requestIDContextKey struct{}
+
+func ContextWithLogger(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx or the default logger, with the fields and the active span
+// of the context attached. A nil ctx is treated like context.Background.
+func FromContext(ctx context.Context) *Logger {
+	if ctx == nil {
+		return Default()
+	}
+	logger, ok := ctx.Value(loggerContextKey{}).(*Logger)
+	if !ok || logger == nil {
+		logger = Default()
+	}
+	if fields := contextFields(ctx); len(fields) > 0 {
+		return logger.With(fields...)
+	}
+	return logger
+}
+
+// ContextWithFields returns a context carrying the given request scoped fields in addition to those already on ctx.
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	existing := ContextFields(ctx)
+	merged := make([]Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsContextKey{}, merged)
+}
+
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDContextKey{}, requestID)
+	return ContextWithFields(ctx, String(RequestIDKey, requestID))
+}
+
+// RequestIDFromContext returns the request ID stored by ContextWithRequestID or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return ContextWithFields(ctx, String(TraceIDKey, traceID))
+}
+
+func ContextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsContextKey{}).([]Field)
+	return fields
+}
+
+func (logger *Logger) TraceCtx(ctx context.Context, msg string) {
+	if logger.enabled(TRACE) {
+		logger.logCtx(ctx, createEvent(TRACE, msg, nil))
+	}
+}
+func (logger *Logger) DebugCtx(ctx context.Context, msg string) {
+	if logger.enabled(DEBUG) {
+		logger.logCtx(ctx, createEvent(DEBUG, msg, nil))
+	}
+}
+func (logger *Logger) InfoCtx(ctx context.Context, msg string) {
+	if logger.enabled(INFO) {
+		logger.logCtx(ctx, createEvent(INFO, msg, nil))
+	}
+}
+func (logger *Logger) WarnCtx(ctx context.Context, msg string) {
+	if logger.enabled(WARN) {
+		logger.logCtx(ctx, createEvent(WARN, msg, nil))
+	}
+}
+func (logger *Logger) ErrorCtx(ctx context.Context, msg string) {
+	if logger.enabled(ERROR) {
+		logger.logCtx(ctx, createEvent(ERROR, msg, nil))
+	}
+}
+func (logger *Logger) FatalCtx(ctx context.Context, msg string) {
+	if logger.enabled(FATAL) {
+		logger.logCtx(ctx, createEvent(FATAL, msg, nil))
+	}
+}
+func (logger *Logger) TraceCtxf(ctx context.Context, format string, args ...any) {
+	if logger.enabled(TRACE) {
+		logger.logCtx(ctx, createEvent(TRACE, fmt.Sprintf(format, args...), nil))
+	}
+}
+func (logger *Logger) DebugCtxf(ctx context.Context, format string, args ...any) {
+	if logger.enabled(DEBUG) {
+		logger.logCtx(ctx, createEvent(DEBUG, fmt.Sprintf(format, args...), nil))
+	}
+}
+func (logger *Logger) InfoCtxf(ctx context.Context, format string, args ...any) {
+	if logger.enabled(INFO) {
+		logger.logCtx(ctx, createEvent(INFO, fmt.Sprintf(format, args...), nil))
+	}
+}
+func (logger *Logger) WarnCtxf(ctx context.Context, format string, args ...any) {
+	if logger.enabled(WARN) {
+		logger.logCtx(ctx, createEvent(WARN, fmt.Sprintf(format, args...), nil))
+	}
+}
+func (logger *Logger) ErrorCtxf(ctx context.Context, format string, args ...any) {
+	if logger.enabled(ERROR) {
+		logger.logCtx(ctx, createEvent(ERROR, fmt.Sprintf(format, args...), nil))
+	}
+}
+func (logger *Logger) FatalCtxf(ctx context.Context, format string, args ...any) {
+	if logger.enabled(FATAL) {
+		logger.logCtx(ctx, createEvent(FATAL, fmt.Sprintf(format, args...), nil))
+	}
+}
+
+func (logger *Logger) logCtx(ctx context.Context, event *Event) {
+	if !logger.omitGoroutine {
+		event.GoroutineId = contextGoroutineName(ctx)
+	}
+	if fields := contextFields(ctx); len(fields) > 0 {
+		event.Fields = make([]Field, 0, len(logger.fields)+len(fields))
+		event.Fields = append(event.Fields, logger.fields...)
+		event.Fields = append(event.Fields, fields...)
+	}
+	logger.log(event)
+}
@@ -0,0 +1,72 @@
+package go_logger_test
+
+import (
+	"context"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestFromContext(t *testing.T) {
+	span := golog.SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"}
+	tests := []struct {
+		name string
+		ctx  func() context.Context
+		want map[string]string
+	}{
+		{
+			name: "request id",
+			ctx:  func() context.Context { return golog.ContextWithRequestID(context.Background(), "r1") },
+			want: map[string]string{golog.RequestIDKey: "r1"},
+		},
+		{
+			name: "trace id",
+			ctx:  func() context.Context { return golog.ContextWithTraceID(context.Background(), "t1") },
+			want: map[string]string{golog.TraceIDKey: "t1"},
+		},
+		{
+			name: "active span",
+			ctx:  func() context.Context { return golog.ContextWithSpan(context.Background(), span) },
+			want: map[string]string{golog.TraceIDKey: span.TraceID, golog.SpanIDKey: span.SpanID},
+		},
+		{
+			name: "trace id and active span",
+			ctx: func() context.Context {
+				return golog.ContextWithSpan(golog.ContextWithTraceID(context.Background(), "t1"), span)
+			},
+			want: map[string]string{golog.TraceIDKey: "t1", golog.SpanIDKey: span.SpanID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, observer := logtest.NewObservedLogger(golog.INFO)
+			golog.FromContext(golog.ContextWithLogger(tt.ctx(), logger)).Info("handled")
+			events := observer.All()
+			if len(events) != 1 {
+				t.Fatalf("%d events, want 1", len(events))
+			}
+			got := map[string]string{}
+			for _, field := range events[0].Fields {
+				if _, ok := got[field.Key]; ok {
+					t.Errorf("field %s is duplicated", field.Key)
+				}
+				got[field.Key] = field.String
+			}
+			for key, value := range tt.want {
+				if got[key] != value {
+					t.Errorf("field %s is %q, want %q", key, got[key], value)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("fields %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromNilContext(t *testing.T) {
+	if logger := golog.FromContext(nil); logger != golog.Default() {
+		t.Errorf("FromContext(nil) returned %v, want the default logger", logger)
+	}
+}
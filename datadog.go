@@ -0,0 +1,78 @@
+package go_logger
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DatadogJSONKeys maps the standard entries to the reserved attributes of Datadog, so the agent
+// recognizes level, logger and error without a custom pipeline.
+var DatadogJSONKeys = JSONKeys{
+	Timestamp: "timestamp",
+	Level:     "status",
+	Logger:    "logger.name",
+	Goroutine: "logger.thread_name",
+	Message:   "message",
+	Error:     "error.message",
+	ErrorType: "error.kind",
+	Caller:    "caller",
+	Function:  "logger.method_name",
+}
+
+// DatadogEncoder writes JSON lines with the keys of DatadogJSONKeys. The error stack, or the stack of
+// the event, is written as text to error.stack, and the trace_id and span_id fields are converted to
+// the decimal dd.trace_id and dd.span_id used to correlate logs with Datadog APM traces.
+type DatadogEncoder struct {
+	JSONEncoder
+}
+
+func NewDatadogEncoder() *DatadogEncoder {
+	return &DatadogEncoder{JSONEncoder{Keys: DatadogJSONKeys}}
+}
+
+func (encoder *DatadogEncoder) Encode(sb *strings.Builder, event *Event) {
+	encodeTo(sb, encoder, event)
+}
+
+func (encoder *DatadogEncoder) encode(sb *buffer, event *Event) {
+	stack := event.Stack
+	if event.Err != nil {
+		if errStack := errorStack(event.Err); len(errStack) > 0 {
+			stack = errStack
+		}
+	}
+	converted := *event
+	converted.Stack = nil
+	converted.Fields = make([]Field, 0, len(event.Fields)+1)
+	if len(stack) > 0 {
+		text := buffer{}
+		writeStackFrames(&text, stack, "")
+		converted.Fields = append(converted.Fields, String("error.stack", text.String()[1:]))
+	}
+	for _, field := range event.Fields {
+		if field.Type == StringType {
+			switch field.Key {
+			case TraceIDKey:
+				field = String("dd.trace_id", datadogID(field.String))
+			case SpanIDKey:
+				field = String("dd.span_id", datadogID(field.String))
+			}
+		}
+		converted.Fields = append(converted.Fields, field)
+	}
+	encoder.JSONEncoder.encode(sb, &converted)
+}
+
+// datadogID converts a hex W3C trace or span id to the decimal form of its lower 64 bits. Ids that
+// are no hex numbers are kept.
+func datadogID(id string) string {
+	low := id
+	if len(low) > 16 {
+		low = low[len(low)-16:]
+	}
+	value, err := strconv.ParseUint(low, 16, 64)
+	if err != nil {
+		return id
+	}
+	return strconv.FormatUint(value, 10)
+}
@@ -0,0 +1,128 @@
+package go_logger
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxDecodeDepth and maxDecodeLength protect decoders against corrupt input.
+	maxDecodeDepth  = 64
+	maxDecodeLength = 64 << 20
+)
+
+var (
+	errDecodeDepth  = errors.New("go_logger: decoded value nested too deeply")
+	errDecodeLength = errors.New("go_logger: decoded value too long")
+)
+
+func readDecodeBytes(r *bufio.Reader, n uint64) ([]byte, error) {
+	if n > maxDecodeLength {
+		return nil, errDecodeLength
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// decodedError is the error of a decoded event; only the message survives encoding.
+type decodedError string
+
+func (err decodedError) Error() string { return string(err) }
+
+// eventFromFields restores an event from the entries of an encoded one. Standard entries are
+// recognized by keys; the error type, causes and stack are dropped since they are derived from the
+// error when the event is encoded again. All other entries become fields.
+func eventFromFields(keys JSONKeys, entries []Field) Event {
+	event := Event{Level: INFO}
+	for _, entry := range entries {
+		switch entry.Key {
+		case "":
+			event.Fields = append(event.Fields, entry)
+		case keys.Timestamp:
+			switch entry.Type {
+			case TimeType:
+				event.Timestamp = entry.time()
+			case StringType:
+				event.Timestamp, _ = time.Parse(time.RFC3339Nano, entry.String)
+			case IntType, UintType, FloatType:
+				event.Timestamp = time.Unix(0, int64(entry.float()*1e9))
+			}
+		case keys.Level:
+			if level, err := ParseLevel(entry.text()); err == nil {
+				event.Level = level
+			}
+		case keys.Logger:
+			event.Logger = entry.text()
+		case keys.Goroutine:
+			event.GoroutineId = entry.text()
+		case keys.Message:
+			event.Message = entry.text()
+		case keys.Error:
+			if entry.Type != AnyType || entry.Interface != nil {
+				event.Err = decodedError(entry.text())
+			}
+		case keys.Caller:
+			function := event.Caller.Function
+			event.Caller = parseCaller(entry.text())
+			event.Caller.Function = function
+		case keys.Function:
+			event.Caller.Function = entry.text()
+		case keys.Stack:
+			frames, _ := entry.Interface.([]Field)
+			for _, frame := range frames {
+				event.Stack = append(event.Stack, parseStackFrame(frame.text()))
+			}
+		case keys.ErrorType, keys.ErrorCauses, keys.ErrorStack:
+		default:
+			event.Fields = append(event.Fields, entry)
+		}
+	}
+	return event
+}
+
+// float returns numeric fields as float64.
+func (field Field) float() float64 {
+	switch field.Type {
+	case UintType:
+		return float64(uint64(field.Integer))
+	case FloatType:
+		return math.Float64frombits(uint64(field.Integer))
+	default:
+		return float64(field.Integer)
+	}
+}
+
+// parseCaller parses the "dir/file.go:line" form of Caller.String.
+func parseCaller(text string) Caller {
+	idx := strings.LastIndexByte(text, ':')
+	if idx < 0 {
+		return Caller{File: text}
+	}
+	line, err := strconv.Atoi(text[idx+1:])
+	if err != nil {
+		return Caller{File: text}
+	}
+	return Caller{File: text[:idx], Line: line}
+}
+
+// parseStackFrame parses the "function (file:line)" form of JSON stacks.
+func parseStackFrame(text string) Caller {
+	idx := strings.LastIndex(text, " (")
+	if idx < 0 || !strings.HasSuffix(text, ")") {
+		return Caller{Function: text}
+	}
+	caller := parseCaller(text[idx+2 : len(text)-1])
+	caller.Function = text[:idx]
+	return caller
+}
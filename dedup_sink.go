@@ -0,0 +1,114 @@
+package go_logger
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DedupSink collapses consecutive identical events like classic syslog: repeats of the last event are
+// dropped and reported as "last message repeated N times" when a different event arrives or the
+// timeout after the first repeat has passed. Events are identical if level, logger, message, error and
+// fields match.
+type DedupSink struct {
+	sink     Sink
+	timeout  time.Duration
+	mutex    sync.Mutex
+	last     *Event
+	repeated int
+	timer    *time.Timer
+}
+
+func NewDedupSink(sink Sink, timeout time.Duration) *DedupSink {
+	return &DedupSink{sink: sink, timeout: timeout}
+}
+
+func (dedup *DedupSink) Write(event *Event) error {
+	dedup.mutex.Lock()
+	defer dedup.mutex.Unlock()
+	if dedup.last != nil && sameEvent(dedup.last, event) {
+		dedup.repeated++
+		if dedup.timer == nil && dedup.timeout > 0 {
+			dedup.timer = time.AfterFunc(dedup.timeout, dedup.timedOut)
+		}
+		return nil
+	}
+	err := dedup.flushRepeated()
+	dedup.last = event.Clone()
+	if writeErr := dedup.sink.Write(event); writeErr != nil {
+		err = writeErr
+	}
+	return err
+}
+
+func (dedup *DedupSink) timedOut() {
+	dedup.mutex.Lock()
+	defer dedup.mutex.Unlock()
+	dedup.timer = nil
+	_ = dedup.flushRepeated()
+}
+
+// flushRepeated writes the repeat notice, if any. The caller must hold the mutex.
+func (dedup *DedupSink) flushRepeated() error {
+	if dedup.timer != nil {
+		dedup.timer.Stop()
+		dedup.timer = nil
+	}
+	if dedup.repeated == 0 {
+		return nil
+	}
+	notice := createEvent(dedup.last.Level, "last message repeated "+strconv.Itoa(dedup.repeated)+" times", nil)
+	notice.Logger = dedup.last.Logger
+	notice.GoroutineId = dedup.last.GoroutineId
+	notice.Fields = []Field{Int("repeated", dedup.repeated)}
+	dedup.repeated = 0
+	err := dedup.sink.Write(notice)
+	releaseEvent(notice)
+	return err
+}
+
+func sameEvent(a, b *Event) bool {
+	if a.Level != b.Level || a.Logger != b.Logger || a.Message != b.Message {
+		return false
+	}
+	if a.Err == nil || b.Err == nil {
+		if a.Err != nil || b.Err != nil {
+			return false
+		}
+	} else if a.Err.Error() != b.Err.Error() {
+		return false
+	}
+	return sameFields(a.Fields, b.Fields)
+}
+
+func sameFields(a, b []Field) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key != b[i].Key || a[i].Type != b[i].Type || a[i].Integer != b[i].Integer || a[i].String != b[i].String {
+			return false
+		}
+		if (a[i].Interface != nil || b[i].Interface != nil) && a[i].text() != b[i].text() {
+			return false
+		}
+	}
+	return true
+}
+
+func (dedup *DedupSink) Flush() error {
+	dedup.mutex.Lock()
+	err := dedup.flushRepeated()
+	dedup.mutex.Unlock()
+	if flushErr := dedup.sink.Flush(); flushErr != nil {
+		err = flushErr
+	}
+	return err
+}
+
+func (dedup *DedupSink) Close() error {
+	dedup.mutex.Lock()
+	_ = dedup.flushRepeated()
+	dedup.mutex.Unlock()
+	return dedup.sink.Close()
+}
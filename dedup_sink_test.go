@@ -0,0 +1,82 @@
+package go_logger_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestDedupSink(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(logger *golog.Logger)
+		want []string
+	}{
+		{
+			name: "repeats are collapsed",
+			log: func(logger *golog.Logger) {
+				for i := 0; i < 3; i++ {
+					logger.Info("retrying")
+				}
+				logger.Info("done")
+			},
+			want: []string{"retrying", "last message repeated 2 times", "done"},
+		},
+		{
+			name: "different fields are kept",
+			log: func(logger *golog.Logger) {
+				logger.InfoEvent().Str("user", "alice").Msg("login")
+				logger.InfoEvent().Str("user", "bob").Msg("login")
+				logger.InfoEvent().Str("user", "bob").Msg("login")
+			},
+			want: []string{"login", "login", "last message repeated 1 times"},
+		},
+		{
+			name: "different field types are kept",
+			log: func(logger *golog.Logger) {
+				logger.InfoEvent().Int("n", 1).Msg("count")
+				logger.InfoEvent().Str("n", "1").Msg("count")
+			},
+			want: []string{"count", "count"},
+		},
+		{
+			name: "different errors are kept",
+			log: func(logger *golog.Logger) {
+				logger.ErrorErr(errors.New("timeout"), "failed")
+				logger.ErrorErr(errors.New("refused"), "failed")
+				logger.ErrorErr(errors.New("refused"), "failed")
+			},
+			want: []string{"failed", "failed", "last message repeated 1 times"},
+		},
+		{
+			name: "different levels are kept",
+			log: func(logger *golog.Logger) {
+				logger.Info("state")
+				logger.Warn("state")
+			},
+			want: []string{"state", "state"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer := logtest.NewObserver()
+			logger := golog.NewLogger("dedup").Sinks(golog.NewDedupSink(observer, time.Hour)).Level(golog.INFO)
+			tt.log(logger)
+			if err := logger.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			events := observer.All()
+			if len(events) != len(tt.want) {
+				t.Fatalf("%d events, want %d", len(events), len(tt.want))
+			}
+			for i, event := range events {
+				if event.Message != tt.want[i] {
+					t.Errorf("event %d is %q, want %q", i, event.Message, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package go_logger
+
+import "sync/atomic"
+
+var defaultLogger atomic.Pointer[Logger]
+
+func init() {
+	defaultLogger.Store(NewLogger("").followConfig())
+}
+
+// Default returns the package default logger used by the top-level logging functions.
+func Default() *Logger { return defaultLogger.Load() }
+
+// SetDefault replaces the package default logger. A nil logger is ignored.
+func SetDefault(logger *Logger) {
+	if logger != nil {
+		defaultLogger.Store(logger)
+	}
+}
+
+func Trace(msg string)                                { Default().Trace(msg) }
+func Debug(msg string)                                { Default().Debug(msg) }
+func Info(msg string)                                 { Default().Info(msg) }
+func Warn(msg string)                                 { Default().Warn(msg) }
+func Error(msg string)                                { Default().Error(msg) }
+func Fatal(msg string)                                { Default().Fatal(msg) }
+func Tracef(format string, args ...any)               { Default().Tracef(format, args...) }
+func Debugf(format string, args ...any)               { Default().Debugf(format, args...) }
+func Infof(format string, args ...any)                { Default().Infof(format, args...) }
+func Warnf(format string, args ...any)                { Default().Warnf(format, args...) }
+func Errorf(format string, args ...any)               { Default().Errorf(format, args...) }
+func Fatalf(format string, args ...any)               { Default().Fatalf(format, args...) }
+func Log(level Level, msg string)                     { Default().Log(level, msg) }
+func Logf(level Level, format string, args ...any)    { Default().Logf(level, format, args...) }
+func TraceErr(err error, msg string)                  { Default().TraceErr(err, msg) }
+func DebugErr(err error, msg string)                  { Default().DebugErr(err, msg) }
+func InfoErr(err error, msg string)                   { Default().InfoErr(err, msg) }
+func WarnErr(err error, msg string)                   { Default().WarnErr(err, msg) }
+func ErrorErr(err error, msg string)                  { Default().ErrorErr(err, msg) }
+func FatalErr(err error, msg string)                  { Default().FatalErr(err, msg) }
+func TraceErrf(err error, format string, args ...any) { Default().TraceErrf(err, format, args...) }
+func DebugErrf(err error, format string, args ...any) { Default().DebugErrf(err, format, args...) }
+func InfoErrf(err error, format string, args ...any)  { Default().InfoErrf(err, format, args...) }
+func WarnErrf(err error, format string, args ...any)  { Default().WarnErrf(err, format, args...) }
+func ErrorErrf(err error, format string, args ...any) { Default().ErrorErrf(err, format, args...) }
+func FatalErrf(err error, format string, args ...any) { Default().FatalErrf(err, format, args...) }
+func Start(operation string) func(err error)          { return Default().Start(operation) }
+func Emit(event *Event)                               { Default().Emit(event) }
@@ -0,0 +1,222 @@
+package go_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ElasticsearchSink batches events and writes them with the bulk API of Elasticsearch or OpenSearch.
+// The index may contain a Go time layout in braces, e.g. "logs-{2006.01.02}", which is formatted with
+// the timestamp of each event in UTC. Requests and single items rejected with 429 are retried with
+// exponential backoff; every rejection is counted as backpressure.
+type ElasticsearchSink struct {
+	url          string
+	index        string
+	client       *http.Client
+	header       http.Header
+	encoder      Encoder
+	level        Level
+	maxRetries   int
+	backoff      time.Duration
+	batcher      *batcher
+	backpressure atomic.Uint64
+	onError      func(error, []*Event)
+}
+
+func NewElasticsearchSink(url, index string, batchSize int, interval time.Duration) *ElasticsearchSink {
+	encoder := NewJSONEncoder()
+	encoder.Keys.Timestamp = "@timestamp"
+	sink := &ElasticsearchSink{
+		url:        strings.TrimSuffix(url, "/") + "/_bulk",
+		index:      index,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		header:     http.Header{},
+		encoder:    encoder,
+		level:      TRACE,
+		maxRetries: 5,
+		backoff:    500 * time.Millisecond,
+	}
+	sink.batcher = newBatcher(batchSize, interval, sink.send)
+	sink.batcher.onError = func(err error, events []*Event) {
+		if sink.onError != nil {
+			sink.onError(err, events)
+		}
+	}
+	return sink
+}
+
+func (sink *ElasticsearchSink) Client(client *http.Client) *ElasticsearchSink {
+	sink.client = client
+	return sink
+}
+
+// Header sets a request header, e.g. Authorization.
+func (sink *ElasticsearchSink) Header(key, value string) *ElasticsearchSink {
+	sink.header.Set(key, value)
+	return sink
+}
+
+func (sink *ElasticsearchSink) Encoder(encoder Encoder) *ElasticsearchSink {
+	sink.encoder = encoder
+	return sink
+}
+
+func (sink *ElasticsearchSink) Level(level Level) *ElasticsearchSink {
+	sink.level = level
+	return sink
+}
+
+func (sink *ElasticsearchSink) Retry(maxRetries int, backoff time.Duration) *ElasticsearchSink {
+	sink.maxRetries = maxRetries
+	sink.backoff = backoff
+	return sink
+}
+
+// OnError is called with batches that could not be indexed after all retries, and with ErrBatchDropped
+// for batches dropped because sending could not keep up.
+func (sink *ElasticsearchSink) OnError(callback func(error, []*Event)) *ElasticsearchSink {
+	sink.onError = callback
+	return sink
+}
+
+// Backpressure returns the number of 429 responses received so far.
+func (sink *ElasticsearchSink) Backpressure() uint64 {
+	return sink.backpressure.Load()
+}
+
+func (sink *ElasticsearchSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	return sink.batcher.add(event)
+}
+
+func (sink *ElasticsearchSink) indexName(t time.Time) string {
+	start := strings.IndexByte(sink.index, '{')
+	end := strings.IndexByte(sink.index, '}')
+	if start < 0 || end < start {
+		return sink.index
+	}
+	return sink.index[:start] + t.UTC().Format(sink.index[start+1:end]) + sink.index[end+1:]
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  any `json:"error"`
+	} `json:"items"`
+}
+
+func (sink *ElasticsearchSink) send(events []*Event) error {
+	backoff := sink.backoff
+	var rejected error
+	for attempt := 0; ; attempt++ {
+		retry, err := sink.bulk(events)
+		if _, ok := err.(rejectedError); ok {
+			rejected = err
+			err = nil
+		}
+		if len(retry) == 0 {
+			if err == nil {
+				return rejected
+			}
+			if !isRetryable(err) {
+				return err
+			}
+			retry = events
+		}
+		if attempt >= sink.maxRetries {
+			if err == nil {
+				err = fmt.Errorf("go_logger: %d events rejected with 429 after %d retries", len(retry), attempt)
+			}
+			return err
+		}
+		events = retry
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// rejectedError counts items rejected permanently, e.g. because of mapping conflicts.
+type rejectedError int
+
+func (rejected rejectedError) Error() string {
+	return "go_logger: " + strconv.Itoa(int(rejected)) + " events rejected by bulk request"
+}
+
+// bulk sends one bulk request and returns the events rejected with 429.
+func (sink *ElasticsearchSink) bulk(events []*Event) ([]*Event, error) {
+	body := bytes.Buffer{}
+	for _, event := range events {
+		body.WriteString(`{"create":{"_index":`)
+		action := buffer{}
+		writeJSONString(&action, sink.indexName(event.Timestamp))
+		body.WriteString(action.String())
+		body.WriteString("}}\n")
+		doc := strings.Builder{}
+		sink.encoder.Encode(&doc, event)
+		body.WriteString(doc.String())
+		if !strings.HasSuffix(doc.String(), "\n") {
+			body.WriteByte('\n')
+		}
+	}
+	request, err := http.NewRequest(http.MethodPost, sink.url, &body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range sink.header {
+		request.Header[key] = values
+	}
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	response, err := sink.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode == http.StatusTooManyRequests {
+		sink.backpressure.Add(1)
+		_, _ = io.Copy(io.Discard, response.Body)
+		return events, nil
+	}
+	if response.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, response.Body)
+		return nil, statusError(response.StatusCode)
+	}
+	result := bulkResponse{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if !result.Errors {
+		return nil, nil
+	}
+	var retry []*Event
+	failed := 0
+	for i, item := range result.Items {
+		for _, status := range item {
+			switch {
+			case status.Status == http.StatusTooManyRequests && i < len(events):
+				retry = append(retry, events[i])
+			case status.Status >= 300:
+				failed++
+			}
+		}
+	}
+	if len(retry) > 0 {
+		sink.backpressure.Add(1)
+	}
+	if failed > 0 {
+		return retry, rejectedError(failed)
+	}
+	return retry, nil
+}
+
+func (sink *ElasticsearchSink) Flush() error { return sink.batcher.flush() }
+func (sink *ElasticsearchSink) Close() error { return sink.batcher.close() }
@@ -0,0 +1,64 @@
+package go_logger_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestElasticsearchSinkIndexName(t *testing.T) {
+	cest := time.FixedZone("CEST", 2*60*60)
+	tests := []struct {
+		name      string
+		index     string
+		timestamp time.Time
+		want      string
+	}{
+		{name: "fixed", index: "logs", timestamp: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), want: "logs"},
+		{name: "daily", index: "logs-{2006.01.02}", timestamp: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), want: "logs-2024.03.01"},
+		{name: "local time before midnight UTC", index: "logs-{2006.01.02}", timestamp: time.Date(2024, 3, 2, 0, 30, 0, 0, cest), want: "logs-2024.03.01"},
+		{name: "monthly", index: "logs-{2006.01}-app", timestamp: time.Date(2024, 4, 1, 1, 0, 0, 0, cest), want: "logs-2024.03-app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mutex sync.Mutex
+			var indices []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				scanner := bufio.NewScanner(r.Body)
+				for scanner.Scan() {
+					var action struct {
+						Create *struct {
+							Index string `json:"_index"`
+						} `json:"create"`
+					}
+					if json.Unmarshal(scanner.Bytes(), &action) == nil && action.Create != nil {
+						mutex.Lock()
+						indices = append(indices, action.Create.Index)
+						mutex.Unlock()
+					}
+				}
+				_, _ = w.Write([]byte(`{"errors":false,"items":[]}`))
+			}))
+			defer server.Close()
+			sink := golog.NewElasticsearchSink(server.URL, tt.index, 10, 0)
+			if err := sink.Write(&golog.Event{Timestamp: tt.timestamp, Level: golog.INFO, Message: "m"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := sink.Close(); err != nil {
+				t.Fatal(err)
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			if !slices.Equal(indices, []string{tt.want}) {
+				t.Errorf("indexed into %q, want %s", indices, tt.want)
+			}
+		})
+	}
+}
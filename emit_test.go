@@ -0,0 +1,61 @@
+package go_logger_test
+
+import (
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestEmit(t *testing.T) {
+	golog.SetGlobalFields(golog.String("service", "api"))
+	defer golog.SetGlobalFields()
+
+	tests := []struct {
+		name  string
+		event func(t *testing.T) *golog.Event
+		want  []string
+	}{
+		{
+			name: "logged event keeps its fields once",
+			event: func(t *testing.T) *golog.Event {
+				logger, observer := logtest.NewObservedLogger(golog.INFO)
+				logger.With(golog.String("component", "db")).Info("first")
+				return observer.All()[0]
+			},
+			want: []string{"forwarder", "service", "component", "request"},
+		},
+		{
+			name: "new event is enriched",
+			event: func(t *testing.T) *golog.Event {
+				return &golog.Event{Level: golog.INFO, Message: "new", Fields: []golog.Field{golog.Int("n", 1)}}
+			},
+			want: []string{"service", "forwarder", "n", "request"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// goroutine fields belong to the goroutine of the subtest
+			defer golog.PushGoroutineFields(golog.String("request", "r1"))()
+			event := tt.event(t)
+			forwarder, observer := logtest.NewObservedLogger(golog.INFO)
+			forwarder.With(golog.Bool("forwarder", true)).Emit(event)
+			emitted := observer.All()
+			if len(emitted) != 1 {
+				t.Fatalf("%d events emitted, want 1", len(emitted))
+			}
+			var keys []string
+			for _, field := range emitted[0].Fields {
+				keys = append(keys, field.Key)
+			}
+			if len(keys) != len(tt.want) {
+				t.Fatalf("fields %v, want %v", keys, tt.want)
+			}
+			for i := range keys {
+				if keys[i] != tt.want[i] {
+					t.Fatalf("fields %v, want %v", keys, tt.want)
+				}
+			}
+		})
+	}
+}
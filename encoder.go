@@ -0,0 +1,164 @@
+package go_logger
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Encoder renders a single event, including the trailing newline.
+type Encoder interface {
+	Encode(sb *strings.Builder, event *Event)
+}
+
+// JSONKeys configures the keys of the standard entries of a JSON line. Entries with an empty key are omitted.
+type JSONKeys struct {
+	Timestamp string
+	Level     string
+	Logger    string
+	Goroutine string
+	Message   string
+	Error     string
+	// ErrorType is the Go type of the error, ErrorCauses the unwrapped chain of causes.
+	ErrorType   string
+	ErrorCauses string
+	// ErrorStack is the stack of errors created by WrapErr or github.com/pkg/errors.
+	ErrorStack string
+	Caller     string
+	Function   string
+	Stack      string
+}
+
+var DefaultJSONKeys = JSONKeys{
+	Timestamp:   "timestamp",
+	Level:       "level",
+	Logger:      "logger",
+	Goroutine:   "goroutineId",
+	Message:     "message",
+	Error:       "error",
+	ErrorType:   "errorType",
+	ErrorCauses: "errorCauses",
+	ErrorStack:  "errorStack",
+	Caller:      "caller",
+	Function:    "function",
+	Stack:       "stack",
+}
+
+// JSONEncoder writes one JSON object per event. Fields are written after the standard entries in the
+// order they were added. Timestamps are formatted as described at Logger.TimestampFormat; epoch
+// layouts are written as numbers.
+type JSONEncoder struct {
+	Keys         JSONKeys
+	TimeLayout   string
+	TimeLocation *time.Location
+	TimeOrigin   time.Time
+}
+
+func NewJSONEncoder() *JSONEncoder {
+	return &JSONEncoder{Keys: DefaultJSONKeys}
+}
+
+var defaultJSONEncoder = NewJSONEncoder()
+
+func (encoder *JSONEncoder) Encode(sb *strings.Builder, event *Event) {
+	encodeTo(sb, encoder, event)
+}
+
+func (encoder *JSONEncoder) encode(sb *buffer, event *Event) {
+	keys := encoder.Keys
+	first := true
+	sb.WriteByte('{')
+	if keys.Timestamp != "" {
+		writeJSONKey(sb, keys.Timestamp, &first)
+		var buf [64]byte
+		if isEpochLayout(encoder.TimeLayout) {
+			sb.Write(appendTimestamp(buf[:0], event.Timestamp, encoder.TimeLayout, nil, time.Time{}))
+		} else {
+			sb.WriteByte('"')
+			sb.Write(appendTimestamp(buf[:0], event.Timestamp, encoder.TimeLayout, encoder.TimeLocation, encoder.TimeOrigin))
+			sb.WriteByte('"')
+		}
+	}
+	if keys.Level != "" {
+		writeJSONKey(sb, keys.Level, &first)
+		writeJSONString(sb, event.Level.Long())
+	}
+	if keys.Logger != "" {
+		writeJSONKey(sb, keys.Logger, &first)
+		writeJSONString(sb, event.Logger)
+	}
+	if keys.Goroutine != "" && event.GoroutineId != "" {
+		writeJSONKey(sb, keys.Goroutine, &first)
+		writeJSONString(sb, event.GoroutineId)
+	}
+	if keys.Message != "" {
+		writeJSONKey(sb, keys.Message, &first)
+		writeJSONString(sb, event.Message)
+	}
+	if keys.Error != "" && event.Err != nil {
+		writeJSONKey(sb, keys.Error, &first)
+		writeJSONString(sb, event.Err.Error())
+	}
+	if event.Err != nil {
+		if keys.ErrorType != "" {
+			writeJSONKey(sb, keys.ErrorType, &first)
+			writeJSONString(sb, errorType(event.Err))
+		}
+		if keys.ErrorCauses != "" {
+			if causes := errorCauses(event.Err); len(causes) > 0 {
+				writeJSONKey(sb, keys.ErrorCauses, &first)
+				writeJSONErrorCauses(sb, causes)
+			}
+		}
+	}
+	if event.Caller.Defined() {
+		if keys.Caller != "" {
+			writeJSONKey(sb, keys.Caller, &first)
+			writeJSONString(sb, event.Caller.String())
+		}
+		if keys.Function != "" {
+			writeJSONKey(sb, keys.Function, &first)
+			writeJSONString(sb, event.Caller.Function)
+		}
+	}
+	if keys.ErrorStack != "" && event.Err != nil {
+		if stack := errorStack(event.Err); len(stack) > 0 {
+			writeJSONKey(sb, keys.ErrorStack, &first)
+			writeJSONStack(sb, stack)
+		}
+	}
+	if keys.Stack != "" && len(event.Stack) > 0 {
+		writeJSONKey(sb, keys.Stack, &first)
+		writeJSONStack(sb, event.Stack)
+	}
+	writeJSONFields(sb, event.Fields, &first)
+	sb.WriteString("}\n")
+}
+
+func writeJSONStack(sb *buffer, stack []Caller) {
+	sb.WriteByte('[')
+	for i, frame := range stack {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		writeJSONString(sb, frame.Function+" ("+frame.File+":"+strconv.Itoa(frame.Line)+")")
+	}
+	sb.WriteByte(']')
+}
+
+func writeJSONFields(sb *buffer, fields []Field, first *bool) {
+	for _, field := range fields {
+		writeJSONKey(sb, field.Key, first)
+		field.writeJSON(sb)
+	}
+}
+
+func writeJSONKey(sb *buffer, key string, first *bool) {
+	if *first {
+		*first = false
+	} else {
+		sb.WriteByte(',')
+	}
+	writeJSONString(sb, key)
+	sb.WriteByte(':')
+}
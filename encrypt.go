@@ -0,0 +1,234 @@
+package go_logger
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// Encrypted streams start with a header of magic and a random salt, from which the key of the stream
+// is derived. Every write becomes a chunk of a 4 byte length and the AES-GCM sealed data, with a
+// counter as nonce and whether it is the final chunk as additional data. Close writes an empty final
+// chunk, so that truncation can be told from a proper end. Streams may be concatenated, e.g. by
+// appending to a file.
+const (
+	encryptMagic    = "GLE1"
+	encryptSaltSize = 16
+	encryptMaxChunk = 1 << 20
+)
+
+var (
+	// ErrDecrypt is returned for data that was changed or encrypted with another key.
+	ErrDecrypt = errors.New("go_logger: decryption failed")
+	// ErrTruncated is returned at the end of a stream that was not closed, e.g. after a crash. All data
+	// up to it has been returned.
+	ErrTruncated = errors.New("go_logger: encrypted stream truncated")
+)
+
+// EncryptingWriter encrypts everything written to it with AES-GCM before passing it on, for logs that
+// must be stored encrypted at rest. Use it as output of a logger or a WriterSink and read it back with
+// DecryptingReader. Each write is sealed on its own, so buffer upstream to keep the overhead of 20 bytes
+// per write small.
+type EncryptingWriter struct {
+	mutex   sync.Mutex
+	out     io.Writer
+	aead    cipher.AEAD
+	counter uint64
+	closed  bool
+}
+
+// NewEncryptingWriter writes the header of a new stream to out. The key must have 16, 24 or 32 bytes.
+func NewEncryptingWriter(out io.Writer, key []byte) (*EncryptingWriter, error) {
+	header := make([]byte, len(encryptMagic)+encryptSaltSize)
+	copy(header, encryptMagic)
+	if _, err := rand.Read(header[len(encryptMagic):]); err != nil {
+		return nil, err
+	}
+	aead, err := streamCipher(key, header[len(encryptMagic):])
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFull(out, string(header)); err != nil {
+		return nil, err
+	}
+	return &EncryptingWriter{out: out, aead: aead}, nil
+}
+
+// streamCipher derives the key of a stream from key and salt.
+func streamCipher(key, salt []byte) (cipher.AEAD, error) {
+	if _, err := aes.NewCipher(key); err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(salt)
+	block, err := aes.NewCipher(mac.Sum(nil)[:len(key)])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (writer *EncryptingWriter) Write(p []byte) (int, error) {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if writer.closed {
+		return 0, ErrSinkClosed
+	}
+	for written := 0; written < len(p); {
+		n := min(len(p)-written, encryptMaxChunk)
+		if err := writer.writeChunk(p[written:written+n], false); err != nil {
+			return written, err
+		}
+		written += n
+	}
+	return len(p), nil
+}
+
+func (writer *EncryptingWriter) writeChunk(data []byte, final bool) error {
+	nonce := make([]byte, writer.aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], writer.counter)
+	writer.counter++
+	chunk := make([]byte, 4, 4+len(data)+writer.aead.Overhead())
+	chunk = writer.aead.Seal(chunk, nonce, data, chunkAdditionalData(final))
+	binary.BigEndian.PutUint32(chunk, uint32(len(chunk)-4))
+	return writeFull(writer.out, string(chunk))
+}
+
+func chunkAdditionalData(final bool) []byte {
+	if final {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+// Sync passes on to the underlying writer.
+func (writer *EncryptingWriter) Sync() error {
+	if out, ok := writer.out.(interface{ Sync() error }); ok {
+		return out.Sync()
+	}
+	return nil
+}
+
+// Close writes the final chunk and closes the underlying writer if it is an io.Closer. os.Stdout and
+// os.Stderr are never closed.
+func (writer *EncryptingWriter) Close() error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if writer.closed {
+		return nil
+	}
+	writer.closed = true
+	err := writer.writeChunk(nil, true)
+	if out, ok := writer.out.(io.Closer); ok && out != os.Stdout && out != os.Stderr {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// DecryptingReader reads the plain text of streams written by EncryptingWriter with the same key. A
+// stream that was not closed is reported as ErrTruncated, also if another stream follows it.
+type DecryptingReader struct {
+	in            *bufio.Reader
+	key           []byte
+	aead          cipher.AEAD
+	counter       uint64
+	buf           []byte
+	err           error
+	allowUnclosed bool
+	unclosed      int
+}
+
+func NewDecryptingReader(r io.Reader, key []byte) *DecryptingReader {
+	return &DecryptingReader{in: bufio.NewReader(r), key: key}
+}
+
+// AllowUnclosed accepts a stream that was not closed if another stream follows, as after a crash and
+// restart appending to the same file. Unclosed reports how many were accepted. A stream that was not
+// closed at the end of the input is still reported as ErrTruncated.
+func (reader *DecryptingReader) AllowUnclosed(allow bool) *DecryptingReader {
+	reader.allowUnclosed = allow
+	return reader
+}
+
+// Unclosed returns the number of streams that were not closed but accepted due to AllowUnclosed.
+func (reader *DecryptingReader) Unclosed() int {
+	return reader.unclosed
+}
+
+func (reader *DecryptingReader) Read(p []byte) (int, error) {
+	for len(reader.buf) == 0 {
+		if reader.err != nil {
+			return 0, reader.err
+		}
+		reader.err = reader.next()
+	}
+	n := copy(p, reader.buf)
+	reader.buf = reader.buf[n:]
+	return n, nil
+}
+
+// next reads the next chunk, or the header of the next stream, into buf.
+func (reader *DecryptingReader) next() error {
+	head, err := reader.in.Peek(4)
+	if err == io.EOF && len(head) == 0 {
+		if reader.aead != nil {
+			return ErrTruncated
+		}
+		return io.EOF
+	}
+	if err != nil {
+		return ErrTruncated
+	}
+	if string(head) == encryptMagic {
+		if reader.aead != nil {
+			if !reader.allowUnclosed {
+				return ErrTruncated
+			}
+			reader.unclosed++
+		}
+		header := make([]byte, len(encryptMagic)+encryptSaltSize)
+		if _, err := io.ReadFull(reader.in, header); err != nil {
+			return ErrTruncated
+		}
+		if reader.aead, err = streamCipher(reader.key, header[len(encryptMagic):]); err != nil {
+			return err
+		}
+		reader.counter = 0
+		return nil
+	}
+	if reader.aead == nil {
+		return ErrDecrypt
+	}
+	size := binary.BigEndian.Uint32(head)
+	if size > encryptMaxChunk+uint32(reader.aead.Overhead()) {
+		return ErrDecrypt
+	}
+	chunk := make([]byte, 4+size)
+	if _, err := io.ReadFull(reader.in, chunk); err != nil {
+		return ErrTruncated
+	}
+	nonce := make([]byte, reader.aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], reader.counter)
+	reader.counter++
+	// only the final chunk is empty
+	final := int(size) == reader.aead.Overhead()
+	plain, err := reader.aead.Open(chunk[4:4], nonce, chunk[4:], chunkAdditionalData(final))
+	if err != nil {
+		return ErrDecrypt
+	}
+	if final {
+		reader.aead = nil
+	}
+	reader.buf = plain
+	return nil
+}
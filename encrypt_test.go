@@ -0,0 +1,120 @@
+package go_logger_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	// stream encrypts lines with key into a new stream, closed or not
+	stream := func(t *testing.T, key []byte, closed bool, lines ...string) []byte {
+		out := bytes.Buffer{}
+		writer, err := golog.NewEncryptingWriter(&out, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range lines {
+			if _, err := writer.Write([]byte(line)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if closed {
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return out.Bytes()
+	}
+	tests := []struct {
+		name          string
+		input         func(t *testing.T) []byte
+		key           []byte
+		allowUnclosed bool
+		want          string
+		wantErr       error
+		wantUnclosed  int
+	}{
+		{
+			name:  "closed stream",
+			input: func(t *testing.T) []byte { return stream(t, key, true, "first\n", "second\n") },
+			want:  "first\nsecond\n",
+		},
+		{
+			name:  "empty stream",
+			input: func(t *testing.T) []byte { return stream(t, key, true) },
+		},
+		{
+			name: "concatenated streams",
+			input: func(t *testing.T) []byte {
+				return append(stream(t, key, true, "first\n"), stream(t, key, true, "second\n")...)
+			},
+			want: "first\nsecond\n",
+		},
+		{
+			name:    "unclosed stream at the end",
+			input:   func(t *testing.T) []byte { return stream(t, key, false, "first\n") },
+			want:    "first\n",
+			wantErr: golog.ErrTruncated,
+		},
+		{
+			name: "unclosed stream followed by another",
+			input: func(t *testing.T) []byte {
+				return append(stream(t, key, false, "first\n"), stream(t, key, true, "second\n")...)
+			},
+			want:    "first\n",
+			wantErr: golog.ErrTruncated,
+		},
+		{
+			name: "unclosed stream allowed",
+			input: func(t *testing.T) []byte {
+				return append(stream(t, key, false, "first\n"), stream(t, key, true, "second\n")...)
+			},
+			allowUnclosed: true,
+			want:          "first\nsecond\n",
+			wantUnclosed:  1,
+		},
+		{
+			name: "cut chunk",
+			input: func(t *testing.T) []byte {
+				data := stream(t, key, true, "first\n")
+				return data[:len(data)-10]
+			},
+			want:    "first\n",
+			wantErr: golog.ErrTruncated,
+		},
+		{
+			name:    "wrong key",
+			input:   func(t *testing.T) []byte { return stream(t, bytes.Repeat([]byte{8}, 32), true, "first\n") },
+			wantErr: golog.ErrDecrypt,
+		},
+		{
+			name: "changed byte",
+			input: func(t *testing.T) []byte {
+				data := stream(t, key, true, "first\n")
+				data[len(data)/2] ^= 1
+				return data
+			},
+			wantErr: golog.ErrDecrypt,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := golog.NewDecryptingReader(bytes.NewReader(tt.input(t)), key).AllowUnclosed(tt.allowUnclosed)
+			got, err := io.ReadAll(reader)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("read %q, want %q", got, tt.want)
+			}
+			if reader.Unclosed() != tt.wantUnclosed {
+				t.Errorf("%d unclosed streams, want %d", reader.Unclosed(), tt.wantUnclosed)
+			}
+		})
+	}
+}
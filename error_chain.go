@@ -0,0 +1,71 @@
+package go_logger
+
+import (
+	"fmt"
+)
+
+// maxErrorCauses bounds the unwrapped chain of an error, guarding against cyclic Unwrap implementations.
+const maxErrorCauses = 32
+
+// errorCauses unwraps err depth first via Unwrap() error and Unwrap() []error (errors.Join). err itself
+// is not included.
+func errorCauses(err error) []error {
+	var causes []error
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			if cause := e.Unwrap(); cause != nil && len(causes) < maxErrorCauses {
+				causes = append(causes, cause)
+				walk(cause)
+			}
+		case interface{ Unwrap() []error }:
+			for _, cause := range e.Unwrap() {
+				if cause != nil && len(causes) < maxErrorCauses {
+					causes = append(causes, cause)
+					walk(cause)
+				}
+			}
+		}
+	}
+	walk(err)
+	return causes
+}
+
+func errorType(err error) string {
+	return fmt.Sprintf("%T", err)
+}
+
+// writeErrorChain writes the causes of err one per line, indented like stack frames.
+func writeErrorChain(sb *buffer, err error) {
+	for _, cause := range errorCauses(err) {
+		sb.WriteString("\n\tcaused by: ")
+		sb.WriteString(errorType(cause))
+		sb.WriteString(": ")
+		writeIndented(sb, cause.Error(), "\t\t")
+	}
+}
+
+// writeJSONErrorCauses writes the causes of err as an array of {"type":...,"message":...} objects.
+func writeJSONErrorCauses(sb *buffer, causes []error) {
+	sb.WriteByte('[')
+	for i, cause := range causes {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(`{"type":`)
+		writeJSONString(sb, errorType(cause))
+		sb.WriteString(`,"message":`)
+		writeJSONString(sb, cause.Error())
+		sb.WriteByte('}')
+	}
+	sb.WriteByte(']')
+}
+
+// ErrorChain makes the PLAIN and PRETTY formats write every cause of an event's error on a line of its
+// own. JSON writes the causes unless JSONKeys.ErrorCauses is empty.
+func (logger *Logger) ErrorChain(errorChain bool) *Logger {
+	logger = logger.derive()
+	logger.errorChain = errorChain
+	return logger
+}
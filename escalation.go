@@ -0,0 +1,62 @@
+package go_logger
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// escalation lowers the level of a logger for a while after a trigger event. It is shared by the
+// copies of a logger like the level.
+type escalation struct {
+	trigger  Level
+	level    Level
+	duration time.Duration
+	events   int64
+	// until is the end of the window in unix nanoseconds, 0 while inactive.
+	until     atomic.Int64
+	remaining atomic.Int64
+}
+
+// EscalateOn lowers the level to level for duration or events events, whichever ends first, after
+// every event at or above trigger. This captures the aftermath of a failure verbosely; afterwards the
+// configured level applies again. Only events below the configured level count against events. A zero
+// duration or events is no limit; both zero disable the escalation.
+func (logger *Logger) EscalateOn(trigger Level, level Level, duration time.Duration, events int) *Logger {
+	logger = logger.derive()
+	logger.escalation = nil
+	if duration > 0 || events > 0 {
+		logger.escalation = &escalation{trigger: trigger, level: level, duration: duration, events: int64(events)}
+	}
+	return logger
+}
+
+// start opens or extends the window after a trigger event at now.
+func (escalation *escalation) start(now time.Time) {
+	escalation.remaining.Store(escalation.events)
+	until := int64(-1)
+	if escalation.duration > 0 {
+		until = now.Add(escalation.duration).UnixNano()
+	}
+	escalation.until.Store(until)
+}
+
+// enabled reports whether events at level may pass while a window is open.
+func (escalation *escalation) enabled(level Level) bool {
+	return level >= escalation.level && escalation.until.Load() != 0
+}
+
+// admit reports whether an event at level below the configured level passes at now and counts it.
+func (escalation *escalation) admit(level Level, now time.Time) bool {
+	if !escalation.enabled(level) {
+		return false
+	}
+	if until := escalation.until.Load(); until > 0 && now.UnixNano() >= until {
+		escalation.until.CompareAndSwap(until, 0)
+		return false
+	}
+	if escalation.events > 0 && escalation.remaining.Add(-1) < 0 {
+		escalation.until.Store(0)
+		return false
+	}
+	return true
+}
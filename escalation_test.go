@@ -0,0 +1,53 @@
+package go_logger_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestEscalateOn(t *testing.T) {
+	// steps are logged at DEBUG, E at ERROR; + advances the clock by a minute
+	tests := []struct {
+		name     string
+		duration time.Duration
+		events   int
+		steps    string
+		want     []string
+	}{
+		{name: "inactive", duration: time.Minute, steps: "a b", want: nil},
+		{name: "window", duration: 90 * time.Second, steps: "a E b + c + d", want: []string{"E", "b", "c"}},
+		{name: "event limit", events: 2, steps: "E a b c", want: []string{"E", "a", "b"}},
+		{name: "first limit ends", duration: time.Hour, events: 1, steps: "E a b", want: []string{"E", "a"}},
+		{name: "extended by trigger", duration: 90 * time.Second, steps: "E + E + a + b", want: []string{"E", "E", "a"}},
+		{name: "disabled", steps: "E a", want: []string{"E"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := golog.NewManualClock(time.Now())
+			logger, observer := logtest.NewObservedLogger(golog.WARN)
+			logger = logger.Clock(clock).EscalateOn(golog.ERROR, golog.DEBUG, tt.duration, tt.events)
+			for _, step := range strings.Fields(tt.steps) {
+				switch step {
+				case "+":
+					clock.Advance(time.Minute)
+				case "E":
+					logger.Error(step)
+				default:
+					logger.Debug(step)
+				}
+			}
+			var got []string
+			for _, event := range observer.All() {
+				got = append(got, event.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
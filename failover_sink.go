@@ -0,0 +1,88 @@
+package go_logger
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// FailoverSink writes to the primary sink and falls back to the secondary if the primary fails. A
+// primary write exceeding the latency budget still counts as delivered, but the following events go
+// to the secondary. While failed over, the primary is retried with one event per recheck interval.
+type FailoverSink struct {
+	primary       Sink
+	secondary     Sink
+	latencyBudget time.Duration
+	recheck       time.Duration
+	mutex         sync.Mutex
+	failedAt      time.Time
+	lastCheck     time.Time
+}
+
+func NewFailoverSink(primary, secondary Sink) *FailoverSink {
+	return &FailoverSink{primary: primary, secondary: secondary, recheck: 30 * time.Second}
+}
+
+// LatencyBudget sets the maximum duration of a primary write. Zero disables the latency check.
+func (failover *FailoverSink) LatencyBudget(budget time.Duration) *FailoverSink {
+	failover.latencyBudget = budget
+	return failover
+}
+
+func (failover *FailoverSink) RecheckInterval(interval time.Duration) *FailoverSink {
+	failover.recheck = interval
+	return failover
+}
+
+// FailedOver reports whether events currently go to the secondary sink.
+func (failover *FailoverSink) FailedOver() bool {
+	failover.mutex.Lock()
+	defer failover.mutex.Unlock()
+	return !failover.failedAt.IsZero()
+}
+
+func (failover *FailoverSink) Write(event *Event) error {
+	if !failover.usePrimary() {
+		return failover.secondary.Write(event)
+	}
+	start := time.Now()
+	err := failover.primary.Write(event)
+	elapsed := time.Since(start)
+	failover.mutex.Lock()
+	if err != nil || (failover.latencyBudget > 0 && elapsed > failover.latencyBudget) {
+		if failover.failedAt.IsZero() {
+			failover.failedAt = time.Now()
+		}
+		failover.lastCheck = time.Now()
+	} else {
+		failover.failedAt = time.Time{}
+	}
+	failover.mutex.Unlock()
+	if err != nil {
+		if secondaryErr := failover.secondary.Write(event); secondaryErr != nil {
+			return errors.Join(err, secondaryErr)
+		}
+	}
+	return nil
+}
+
+func (failover *FailoverSink) usePrimary() bool {
+	failover.mutex.Lock()
+	defer failover.mutex.Unlock()
+	if failover.failedAt.IsZero() {
+		return true
+	}
+	if time.Since(failover.lastCheck) >= failover.recheck {
+		failover.lastCheck = time.Now()
+		return true
+	}
+	return false
+}
+
+func (failover *FailoverSink) Flush() error {
+	return errors.Join(failover.primary.Flush(), failover.secondary.Flush())
+}
+
+func (failover *FailoverSink) Close() error {
+	return errors.Join(failover.primary.Close(), failover.secondary.Close())
+}
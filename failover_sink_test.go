@@ -0,0 +1,100 @@
+package go_logger_test
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// flakySink records messages; writes fail while err is set and take delay.
+type flakySink struct {
+	mutex    sync.Mutex
+	messages []string
+	err      error
+	delay    time.Duration
+}
+
+func (sink *flakySink) Write(event *golog.Event) error {
+	time.Sleep(sink.delay)
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.err != nil {
+		return sink.err
+	}
+	sink.messages = append(sink.messages, event.Message)
+	return nil
+}
+
+func (sink *flakySink) Flush() error { return nil }
+func (sink *flakySink) Close() error { return nil }
+
+func TestFailoverSink(t *testing.T) {
+	down := errors.New("connection refused")
+	tests := []struct {
+		name          string
+		primary       *flakySink
+		budget        time.Duration
+		recheck       time.Duration
+		recover       bool
+		wantPrimary   []string
+		wantSecondary []string
+		wantFailed    bool
+	}{
+		{
+			name:        "healthy primary",
+			primary:     &flakySink{},
+			recheck:     time.Hour,
+			wantPrimary: []string{"1", "2", "3"},
+		},
+		{
+			name:          "failing primary",
+			primary:       &flakySink{err: down},
+			recheck:       time.Hour,
+			wantSecondary: []string{"1", "2", "3"},
+			wantFailed:    true,
+		},
+		{
+			name:          "slow primary",
+			primary:       &flakySink{delay: 20 * time.Millisecond},
+			budget:        time.Millisecond,
+			recheck:       time.Hour,
+			wantPrimary:   []string{"1"},
+			wantSecondary: []string{"2", "3"},
+			wantFailed:    true,
+		},
+		{
+			name:          "recovered primary",
+			primary:       &flakySink{err: down},
+			recheck:       time.Nanosecond,
+			recover:       true,
+			wantPrimary:   []string{"2", "3"},
+			wantSecondary: []string{"1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secondary := &flakySink{}
+			failover := golog.NewFailoverSink(tt.primary, secondary).LatencyBudget(tt.budget).RecheckInterval(tt.recheck)
+			logger := golog.NewLogger("failover").Sinks(failover).Level(golog.INFO)
+			logger.Info("1")
+			if tt.recover {
+				tt.primary.err = nil
+			}
+			logger.Info("2")
+			logger.Info("3")
+			if !slices.Equal(tt.primary.messages, tt.wantPrimary) {
+				t.Errorf("primary got %v, want %v", tt.primary.messages, tt.wantPrimary)
+			}
+			if !slices.Equal(secondary.messages, tt.wantSecondary) {
+				t.Errorf("secondary got %v, want %v", secondary.messages, tt.wantSecondary)
+			}
+			if failover.FailedOver() != tt.wantFailed {
+				t.Errorf("FailedOver is %v, want %v", failover.FailedOver(), tt.wantFailed)
+			}
+		})
+	}
+}
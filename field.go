@@ -0,0 +1,335 @@
+package go_logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+type FieldType uint8
+
+const (
+	UnknownType FieldType = iota
+	StringType
+	IntType
+	UintType
+	FloatType
+	BoolType
+	DurationType
+	TimeType
+	ErrorType
+	ObjectType
+	AnyType
+	ArrayType
+)
+
+// Field is a typed key/value pair. Scalar values are kept in Integer and String so that
+// encoding them neither boxes into an interface nor needs reflection.
+type Field struct {
+	Key       string
+	Type      FieldType
+	Integer   int64
+	String    string
+	Interface any
+}
+
+func String(key string, value string) Field {
+	return Field{Key: key, Type: StringType, String: value}
+}
+func Int(key string, value int) Field {
+	return Field{Key: key, Type: IntType, Integer: int64(value)}
+}
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Type: IntType, Integer: value}
+}
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Type: UintType, Integer: int64(value)}
+}
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Type: FloatType, Integer: int64(math.Float64bits(value))}
+}
+func Bool(key string, value bool) Field {
+	var i int64
+	if value {
+		i = 1
+	}
+	return Field{Key: key, Type: BoolType, Integer: i}
+}
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: DurationType, Integer: int64(value)}
+}
+
+// Time stores value as Unix nanoseconds and its location. Times outside the years 1678 to 2262, which
+// includes the zero time, do not fit and are kept as they are.
+func Time(key string, value time.Time) Field {
+	nanos := value.UnixNano()
+	if !time.Unix(0, nanos).Equal(value) {
+		return Field{Key: key, Type: TimeType, Interface: value}
+	}
+	return Field{Key: key, Type: TimeType, Integer: nanos, Interface: value.Location()}
+}
+
+// Err returns a field with key "error". A nil error is kept and rendered as null.
+func Err(err error) Field {
+	return Field{Key: "error", Type: ErrorType, Interface: err}
+}
+
+// Object groups fields into a nested object.
+func Object(key string, fields ...Field) Field {
+	return Field{Key: key, Type: ObjectType, Interface: fields}
+}
+
+// Array groups values into an array; the keys of values are ignored.
+func Array(key string, values ...Field) Field {
+	return Field{Key: key, Type: ArrayType, Interface: values}
+}
+
+// LogObjectMarshaler is implemented by types encoding themselves as an object of fields.
+type LogObjectMarshaler interface {
+	MarshalLogObject() []Field
+}
+
+// LogArrayMarshaler is implemented by types encoding themselves as an array; keys of the returned fields
+// are ignored.
+type LogArrayMarshaler interface {
+	MarshalLogArray() []Field
+}
+
+func ObjectOf(key string, value LogObjectMarshaler) Field {
+	return Object(key, value.MarshalLogObject()...)
+}
+func ArrayOf(key string, value LogArrayMarshaler) Field {
+	return Array(key, value.MarshalLogArray()...)
+}
+
+// Stringer returns a string field with the result of value.String().
+func Stringer(key string, value fmt.Stringer) Field {
+	return String(key, value.String())
+}
+
+// Any picks the typed constructor matching the dynamic type of value and falls back to
+// fmt resp. encoding/json for everything else.
+func Any(key string, value any) Field {
+	switch v := value.(type) {
+	case string:
+		return String(key, v)
+	case int:
+		return Int(key, v)
+	case int8:
+		return Int64(key, int64(v))
+	case int16:
+		return Int64(key, int64(v))
+	case int32:
+		return Int64(key, int64(v))
+	case int64:
+		return Int64(key, v)
+	case uint:
+		return Uint64(key, uint64(v))
+	case uint8:
+		return Uint64(key, uint64(v))
+	case uint16:
+		return Uint64(key, uint64(v))
+	case uint32:
+		return Uint64(key, uint64(v))
+	case uint64:
+		return Uint64(key, v)
+	case float32:
+		return Float64(key, float64(v))
+	case float64:
+		return Float64(key, v)
+	case bool:
+		return Bool(key, v)
+	case time.Duration:
+		return Duration(key, v)
+	case time.Time:
+		return Time(key, v)
+	case LogObjectMarshaler:
+		return ObjectOf(key, v)
+	case LogArrayMarshaler:
+		return ArrayOf(key, v)
+	case error:
+		return Field{Key: key, Type: ErrorType, Interface: v}
+	case []Field:
+		return Object(key, v...)
+	default:
+		return Field{Key: key, Type: AnyType, Interface: v}
+	}
+}
+
+func (field Field) time() time.Time {
+	if t, ok := field.Interface.(time.Time); ok {
+		return t
+	}
+	t := time.Unix(0, field.Integer)
+	if loc, ok := field.Interface.(*time.Location); ok && loc != nil {
+		t = t.In(loc)
+	}
+	return t
+}
+
+func (field Field) text() string {
+	sb := buffer{}
+	field.writeText(&sb)
+	return sb.String()
+}
+
+func (field Field) writeText(sb *buffer) {
+	var buf [64]byte
+	switch field.Type {
+	case StringType:
+		sb.WriteString(field.String)
+	case IntType:
+		if h, ok := field.Interface.(humanizer); ok {
+			h.write(sb, field.Integer)
+			return
+		}
+		sb.Write(strconv.AppendInt(buf[:0], field.Integer, 10))
+	case UintType:
+		sb.Write(strconv.AppendUint(buf[:0], uint64(field.Integer), 10))
+	case FloatType:
+		sb.Write(strconv.AppendFloat(buf[:0], math.Float64frombits(uint64(field.Integer)), 'g', -1, 64))
+	case BoolType:
+		sb.Write(strconv.AppendBool(buf[:0], field.Integer != 0))
+	case DurationType:
+		sb.WriteString(time.Duration(field.Integer).String())
+	case TimeType:
+		sb.Write(field.time().AppendFormat(buf[:0], time.RFC3339Nano))
+	case ErrorType:
+		if err, ok := field.Interface.(error); ok && err != nil {
+			sb.WriteString(err.Error())
+		} else {
+			sb.WriteString("<nil>")
+		}
+	case ObjectType:
+		fields, _ := field.Interface.([]Field)
+		sb.WriteByte('{')
+		for i, f := range fields {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(f.Key)
+			sb.WriteByte('=')
+			f.writeText(sb)
+		}
+		sb.WriteByte('}')
+	case ArrayType:
+		values, _ := field.Interface.([]Field)
+		sb.WriteByte('[')
+		for i, value := range values {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			value.writeText(sb)
+		}
+		sb.WriteByte(']')
+	default:
+		sb.WriteString(fmt.Sprint(field.Interface))
+	}
+}
+
+func (field Field) writeJSON(sb *buffer) {
+	var buf [64]byte
+	switch field.Type {
+	case StringType:
+		writeJSONString(sb, field.String)
+	case IntType:
+		sb.Write(strconv.AppendInt(buf[:0], field.Integer, 10))
+	case UintType:
+		sb.Write(strconv.AppendUint(buf[:0], uint64(field.Integer), 10))
+	case FloatType:
+		f := math.Float64frombits(uint64(field.Integer))
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			sb.WriteByte('"')
+			sb.Write(strconv.AppendFloat(buf[:0], f, 'g', -1, 64))
+			sb.WriteByte('"')
+		} else {
+			sb.Write(strconv.AppendFloat(buf[:0], f, 'g', -1, 64))
+		}
+	case BoolType:
+		sb.Write(strconv.AppendBool(buf[:0], field.Integer != 0))
+	case DurationType:
+		writeJSONString(sb, time.Duration(field.Integer).String())
+	case TimeType:
+		sb.WriteByte('"')
+		sb.Write(field.time().AppendFormat(buf[:0], time.RFC3339Nano))
+		sb.WriteByte('"')
+	case ErrorType:
+		if err, ok := field.Interface.(error); ok && err != nil {
+			writeJSONString(sb, err.Error())
+		} else {
+			sb.WriteString("null")
+		}
+	case ObjectType:
+		fields, _ := field.Interface.([]Field)
+		first := true
+		sb.WriteByte('{')
+		writeJSONFields(sb, fields, &first)
+		sb.WriteByte('}')
+	case ArrayType:
+		values, _ := field.Interface.([]Field)
+		sb.WriteByte('[')
+		for i, value := range values {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			value.writeJSON(sb)
+		}
+		sb.WriteByte(']')
+	default:
+		value, err := json.Marshal(field.Interface)
+		if err != nil {
+			writeJSONString(sb, fmt.Sprint(field.Interface))
+		} else {
+			sb.Write(value)
+		}
+	}
+}
+
+const hex = "0123456789abcdef"
+
+func writeJSONString(sb *buffer, s string) {
+	sb.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' {
+				i++
+				continue
+			}
+			sb.WriteString(s[start:i])
+			switch b {
+			case '"', '\\':
+				sb.WriteByte('\\')
+				sb.WriteByte(b)
+			case '\n':
+				sb.WriteString("\\n")
+			case '\r':
+				sb.WriteString("\\r")
+			case '\t':
+				sb.WriteString("\\t")
+			default:
+				sb.WriteString("\\u00")
+				sb.WriteByte(hex[b>>4])
+				sb.WriteByte(hex[b&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			sb.WriteString(s[start:i])
+			sb.WriteString("\\ufffd")
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	sb.WriteString(s[start:])
+	sb.WriteByte('"')
+}
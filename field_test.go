@@ -0,0 +1,39 @@
+package go_logger_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestTimeField(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	tests := []struct {
+		name  string
+		value time.Time
+	}{
+		{"utc", time.Date(2024, 5, 17, 10, 30, 0, 123456789, time.UTC)},
+		{"location", time.Date(2024, 5, 17, 10, 30, 0, 0, cet)},
+		{"zero", time.Time{}},
+		{"before 1678", time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"after 2262", time.Date(3000, 12, 31, 23, 59, 59, 999, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &golog.Event{Timestamp: tt.value, Level: golog.INFO, Message: "at", Fields: []golog.Field{golog.Time("at", tt.value)}}
+			want := tt.value.Format(time.RFC3339Nano)
+			sb := strings.Builder{}
+			golog.NewJSONEncoder().Encode(&sb, event)
+			if !strings.Contains(sb.String(), `"at":"`+want+`"`) {
+				t.Errorf("JSON %s does not contain %s", sb.String(), want)
+			}
+			sb.Reset()
+			golog.NewPlainEncoder(false).Encode(&sb, event)
+			if !strings.Contains(sb.String(), "at="+want) {
+				t.Errorf("plain %s does not contain %s", sb.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package go_logger
+
+import "os"
+
+// Advisory locks are only taken on unix; elsewhere shared files rely on appending every line with a
+// single write.
+func lockFile(*os.File) error {
+	return nil
+}
+
+func unlockFile(*os.File) error {
+	return nil
+}
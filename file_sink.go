@@ -0,0 +1,489 @@
+package go_logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Rotation int
+
+const (
+	RotateNever Rotation = iota
+	RotateHourly
+	RotateDaily
+)
+
+const backupTimeFormat = "20060102T150405.000"
+
+// FileSink writes events to a file and rotates it by size, age or time boundary. Rotation is checked
+// before each write against the timestamp of the event, so a line is never split across two files.
+// Rotated files are renamed to <name>-<timestamp><ext> and optionally gzipped in the background.
+type FileSink struct {
+	mutex      sync.Mutex
+	compressWg sync.WaitGroup
+	path       string
+	encoder    Encoder
+	level      Level
+	maxSize    int64
+	maxAge     time.Duration
+	rotation   Rotation
+	maxBackups int
+	compress   bool
+	shared     bool
+	// cleanupMutex serializes all changes to rotated files: compression, deletion and retention.
+	cleanupMutex  sync.Mutex
+	maxBackupAge  time.Duration
+	maxTotalSize  int64
+	compressAfter time.Duration
+	janitorDone   chan struct{}
+	janitor       sync.WaitGroup
+	file          *os.File
+	size          int64
+	openedAt      time.Time
+	boundary      time.Time
+}
+
+func NewFileSink(path string, encoder Encoder) (*FileSink, error) {
+	sink := &FileSink{path: path, encoder: encoder, level: TRACE}
+	if err := sink.open(time.Now()); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+func (sink *FileSink) Level(level Level) *FileSink {
+	sink.level = level
+	return sink
+}
+
+// MaxSize rotates the file before a write would make it exceed size bytes. Zero disables size rotation.
+func (sink *FileSink) MaxSize(size int64) *FileSink {
+	sink.maxSize = size
+	return sink
+}
+
+// MaxAge rotates the file once it has been open for longer than age. Zero disables age rotation.
+func (sink *FileSink) MaxAge(age time.Duration) *FileSink {
+	sink.maxAge = age
+	return sink
+}
+
+func (sink *FileSink) Rotation(rotation Rotation) *FileSink {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.rotation = rotation
+	sink.boundary = nextBoundary(sink.openedAt, rotation)
+	return sink
+}
+
+// MaxBackups limits the number of rotated files kept next to the active one. Zero keeps all of them.
+func (sink *FileSink) MaxBackups(backups int) *FileSink {
+	sink.maxBackups = backups
+	return sink
+}
+
+func (sink *FileSink) Compress(compress bool) *FileSink {
+	sink.compress = compress
+	return sink
+}
+
+// Shared makes the sink safe for several processes, or several sinks in one process, writing the same
+// file. Every line is appended with a single write under an exclusive advisory lock, the size for
+// rotation is taken from the file itself, and a rotation by another writer is noticed before the next
+// write. Locks are only taken on unix.
+func (sink *FileSink) Shared(shared bool) *FileSink {
+	sink.shared = shared
+	return sink
+}
+
+// MaxBackupAge deletes rotated files older than age, judged by the time in their name. Zero keeps them.
+func (sink *FileSink) MaxBackupAge(age time.Duration) *FileSink {
+	sink.maxBackupAge = age
+	return sink
+}
+
+// MaxTotalSize deletes the oldest rotated files while they and the active file together exceed size
+// bytes. Zero disables the cap.
+func (sink *FileSink) MaxTotalSize(size int64) *FileSink {
+	sink.maxTotalSize = size
+	return sink
+}
+
+// CompressAfter gzips rotated files once they are older than age, keeping recent ones readable.
+// Compress gzips them right after rotation instead.
+func (sink *FileSink) CompressAfter(age time.Duration) *FileSink {
+	sink.compressAfter = age
+	return sink
+}
+
+// Janitor applies the retention settings every interval in the background until the sink is closed,
+// besides after every rotation. Configure the retention before starting the janitor.
+func (sink *FileSink) Janitor(interval time.Duration) *FileSink {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.janitorDone != nil || interval <= 0 {
+		return sink
+	}
+	sink.janitorDone = make(chan struct{})
+	sink.janitor.Add(1)
+	go func(done chan struct{}) {
+		defer sink.janitor.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				_ = sink.Cleanup()
+			}
+		}
+	}(sink.janitorDone)
+	return sink
+}
+
+func (sink *FileSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	buf := encodeEvent(sink.encoder, event)
+	defer putBuffer(buf)
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.file == nil {
+		return os.ErrClosed
+	}
+	if sink.shared {
+		if err := sink.lock(); err != nil {
+			return err
+		}
+		defer func() { _ = unlockFile(sink.file) }()
+	}
+	if sink.shouldRotate(event.Timestamp, int64(buf.Len())) {
+		if err := sink.rotate(event.Timestamp); err != nil {
+			return err
+		}
+		if sink.shared {
+			if err := sink.lock(); err != nil {
+				return err
+			}
+		}
+	}
+	n, err := sink.file.Write(*buf)
+	sink.size += int64(n)
+	return err
+}
+
+// Rotate forces a rotation independent of the configured triggers.
+func (sink *FileSink) Rotate() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.file == nil {
+		return os.ErrClosed
+	}
+	if sink.shared {
+		if err := sink.lock(); err != nil {
+			return err
+		}
+		defer func() { _ = unlockFile(sink.file) }()
+	}
+	return sink.rotate(time.Now())
+}
+
+func (sink *FileSink) Flush() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.file == nil {
+		return nil
+	}
+	return sink.file.Sync()
+}
+
+// Close closes the file, stops the janitor and waits for pending compressions of rotated files.
+func (sink *FileSink) Close() error {
+	sink.mutex.Lock()
+	var err error
+	if sink.file != nil {
+		err = sink.file.Close()
+		sink.file = nil
+	}
+	if sink.janitorDone != nil {
+		close(sink.janitorDone)
+		sink.janitorDone = nil
+	}
+	sink.mutex.Unlock()
+	sink.janitor.Wait()
+	sink.compressWg.Wait()
+	return err
+}
+
+func (sink *FileSink) shouldRotate(now time.Time, n int64) bool {
+	if sink.maxSize > 0 && sink.size > 0 && sink.size+n > sink.maxSize {
+		return true
+	}
+	if sink.maxAge > 0 && now.Sub(sink.openedAt) >= sink.maxAge {
+		return true
+	}
+	return !sink.boundary.IsZero() && !now.Before(sink.boundary)
+}
+
+func (sink *FileSink) open(now time.Time) error {
+	if dir := filepath.Dir(sink.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	file, err := os.OpenFile(sink.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	sink.file = file
+	sink.size = info.Size()
+	sink.openedAt = now
+	sink.boundary = nextBoundary(now, sink.rotation)
+	return nil
+}
+
+// lock locks the file of a shared sink and updates its size. If another writer rotated the file in
+// the meantime, the new file at the path is opened and locked instead.
+func (sink *FileSink) lock() error {
+	for {
+		if err := lockFile(sink.file); err != nil {
+			return err
+		}
+		info, err := sink.file.Stat()
+		if err != nil {
+			_ = unlockFile(sink.file)
+			return err
+		}
+		if current, err := os.Stat(sink.path); err == nil && os.SameFile(info, current) {
+			sink.size = info.Size()
+			return nil
+		}
+		_ = sink.file.Close()
+		sink.file = nil
+		if err := sink.open(time.Now()); err != nil {
+			return err
+		}
+	}
+}
+
+func (sink *FileSink) rotate(now time.Time) error {
+	backup := sink.backupName(now)
+	if sink.shared {
+		// renamed while still locked, so that no other writer rotates the same file again
+		if err := os.Rename(sink.path, backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := sink.file.Close(); err != nil {
+		return err
+	}
+	sink.file = nil
+	if !sink.shared {
+		if err := os.Rename(sink.path, backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := sink.open(now); err != nil {
+		return err
+	}
+	sink.compressWg.Add(1)
+	go func() {
+		defer sink.compressWg.Done()
+		if sink.compress {
+			sink.cleanupMutex.Lock()
+			err := compressFile(backup)
+			sink.cleanupMutex.Unlock()
+			if err != nil {
+				return
+			}
+		}
+		_ = sink.Cleanup()
+	}()
+	return nil
+}
+
+func (sink *FileSink) backupName(now time.Time) string {
+	ext := filepath.Ext(sink.path)
+	base := strings.TrimSuffix(sink.path, ext) + "-" + now.Format(backupTimeFormat)
+	name := base + ext
+	for i := 1; fileExists(name) || fileExists(name+".gz"); i++ {
+		name = base + "." + strconv.Itoa(i) + ext
+	}
+	return name
+}
+
+type backupFile struct {
+	path    string
+	stamp   string
+	counter int
+}
+
+// time returns the rotation time in the name of the backup.
+func (backup backupFile) time() time.Time {
+	t, _ := time.ParseInLocation(backupTimeFormat, backup.stamp, time.Local)
+	return t
+}
+
+// backups returns the rotated files of this sink, oldest first.
+func (sink *FileSink) backups() []backupFile {
+	ext := filepath.Ext(sink.path)
+	prefix := filepath.Base(strings.TrimSuffix(sink.path, ext)) + "-"
+	entries, err := os.ReadDir(filepath.Dir(sink.path))
+	if err != nil {
+		return nil
+	}
+	var found []backupFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		trimmed := strings.TrimSuffix(name, ".gz")
+		if !strings.HasSuffix(trimmed, ext) {
+			continue
+		}
+		stamp := strings.TrimSuffix(trimmed, ext)[len(prefix):]
+		if len(stamp) < len(backupTimeFormat) {
+			continue
+		}
+		if _, err := time.Parse(backupTimeFormat, stamp[:len(backupTimeFormat)]); err != nil {
+			continue
+		}
+		counter, _ := strconv.Atoi(strings.TrimPrefix(stamp[len(backupTimeFormat):], "."))
+		found = append(found, backupFile{
+			path:    filepath.Join(filepath.Dir(sink.path), name),
+			stamp:   stamp[:len(backupTimeFormat)],
+			counter: counter,
+		})
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].stamp != found[j].stamp {
+			return found[i].stamp < found[j].stamp
+		}
+		return found[i].counter < found[j].counter
+	})
+	return found
+}
+
+// Cleanup applies the retention settings now: it compresses rotated files older than CompressAfter
+// and deletes those beyond MaxBackups, older than MaxBackupAge or over MaxTotalSize, oldest first.
+func (sink *FileSink) Cleanup() error {
+	sink.cleanupMutex.Lock()
+	defer sink.cleanupMutex.Unlock()
+	now := time.Now()
+	var errs []error
+	backups := sink.backups()
+	remove := func(i int) {
+		if err := os.Remove(backups[i].path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	deleted := 0
+	for i, backup := range backups {
+		expired := sink.maxBackupAge > 0 && now.Sub(backup.time()) > sink.maxBackupAge
+		if expired || sink.maxBackups > 0 && len(backups)-i > sink.maxBackups {
+			remove(i)
+			deleted = i + 1
+		}
+	}
+	backups = backups[deleted:]
+	if sink.maxTotalSize > 0 {
+		deleted = 0
+		total := int64(0)
+		sizes := make([]int64, len(backups))
+		if info, err := os.Stat(sink.path); err == nil {
+			total = info.Size()
+		}
+		for i, backup := range backups {
+			if info, err := os.Stat(backup.path); err == nil {
+				sizes[i] = info.Size()
+				total += sizes[i]
+			}
+		}
+		for i := 0; i < len(backups) && total > sink.maxTotalSize; i++ {
+			remove(i)
+			total -= sizes[i]
+			deleted = i + 1
+		}
+		backups = backups[deleted:]
+	}
+	if sink.compressAfter > 0 {
+		for _, backup := range backups {
+			if !strings.HasSuffix(backup.path, ".gz") && now.Sub(backup.time()) > sink.compressAfter {
+				if err := compressFile(backup.path); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func nextBoundary(now time.Time, rotation Rotation) time.Time {
+	switch rotation {
+	case RotateHourly:
+		return time.Date(now.Year(), now.Month(), now.Day(), now.Hour()+1, 0, 0, 0, now.Location())
+	case RotateDaily:
+		return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+func compressFile(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(path+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(out)
+	if _, err = io.Copy(gz, in); err == nil {
+		err = gz.Close()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Reopen closes and reopens the file at its path without rotating, for use with external tools like logrotate.
+func (sink *FileSink) Reopen() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.file == nil {
+		return os.ErrClosed
+	}
+	if err := sink.file.Close(); err != nil {
+		return err
+	}
+	sink.file = nil
+	return sink.open(time.Now())
+}
@@ -0,0 +1,197 @@
+package go_logger_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// logFiles returns the names of the files in dir, sorted.
+func logFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func writeAt(t *testing.T, sink golog.Sink, at time.Time, msg string) {
+	t.Helper()
+	if err := sink.Write(&golog.Event{Timestamp: at, Level: golog.INFO, Logger: "file", Message: msg}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		configure func(sink *golog.FileSink)
+		times     []time.Time
+		wantFiles int
+		wantGzip  int
+	}{
+		{
+			name:      "no rotation",
+			configure: func(sink *golog.FileSink) {},
+			times:     []time.Time{now, now, now},
+			wantFiles: 1,
+		},
+		{
+			name:      "by size",
+			configure: func(sink *golog.FileSink) { sink.MaxSize(10) },
+			times:     []time.Time{now, now.Add(time.Millisecond), now.Add(2 * time.Millisecond)},
+			wantFiles: 3,
+		},
+		{
+			name:      "by age",
+			configure: func(sink *golog.FileSink) { sink.MaxAge(time.Hour) },
+			times:     []time.Time{now, now.Add(30 * time.Minute), now.Add(2 * time.Hour)},
+			wantFiles: 2,
+		},
+		{
+			name:      "daily",
+			configure: func(sink *golog.FileSink) { sink.Rotation(golog.RotateDaily) },
+			times:     []time.Time{now, now.Add(24 * time.Hour), now.Add(48 * time.Hour)},
+			wantFiles: 3,
+		},
+		{
+			name:      "max backups",
+			configure: func(sink *golog.FileSink) { sink.MaxSize(10).MaxBackups(1) },
+			times:     []time.Time{now, now.Add(time.Second), now.Add(2 * time.Second), now.Add(3 * time.Second)},
+			wantFiles: 2,
+		},
+		{
+			name:      "compressed",
+			configure: func(sink *golog.FileSink) { sink.MaxSize(10).Compress(true) },
+			times:     []time.Time{now, now.Add(time.Second), now.Add(2 * time.Second)},
+			wantFiles: 3,
+			wantGzip:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			sink, err := golog.NewFileSink(filepath.Join(dir, "app.log"), golog.NewJSONEncoder())
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.configure(sink)
+			for i, at := range tt.times {
+				writeAt(t, sink, at, "line "+strconv.Itoa(i))
+			}
+			if err := sink.Close(); err != nil {
+				t.Fatal(err)
+			}
+			files := logFiles(t, dir)
+			if len(files) != tt.wantFiles {
+				t.Fatalf("files %v, want %d", files, tt.wantFiles)
+			}
+			gzipped := 0
+			for _, name := range files {
+				if strings.HasSuffix(name, ".gz") {
+					gzipped++
+				}
+			}
+			if gzipped != tt.wantGzip {
+				t.Errorf("files %v, want %d gzipped", files, tt.wantGzip)
+			}
+			active, err := os.ReadFile(filepath.Join(dir, "app.log"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if last := "line " + strconv.Itoa(len(tt.times)-1); !strings.Contains(string(active), last) {
+				t.Errorf("active file %q does not contain the last line", active)
+			}
+		})
+	}
+}
+
+func TestFileSinkRetention(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name      string
+		configure func(sink *golog.FileSink)
+		wantAges  []string
+	}{
+		{
+			name:      "keep all",
+			configure: func(sink *golog.FileSink) {},
+			wantAges:  []string{"10d", "5d", "1d"},
+		},
+		{
+			name:      "max backup age",
+			configure: func(sink *golog.FileSink) { sink.MaxBackupAge(3 * day) },
+			wantAges:  []string{"1d"},
+		},
+		{
+			name:      "max total size",
+			configure: func(sink *golog.FileSink) { sink.MaxTotalSize(250) },
+			wantAges:  []string{"1d"},
+		},
+		{
+			name:      "compress after",
+			configure: func(sink *golog.FileSink) { sink.CompressAfter(2 * day) },
+			wantAges:  []string{"10d.gz", "5d.gz", "1d"},
+		},
+		{
+			name: "all policies",
+			configure: func(sink *golog.FileSink) {
+				sink.MaxBackupAge(7 * day).MaxTotalSize(1 << 20).CompressAfter(2 * day)
+			},
+			wantAges: []string{"5d.gz", "1d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			sink, err := golog.NewFileSink(filepath.Join(dir, "app.log"), golog.NewJSONEncoder())
+			if err != nil {
+				t.Fatal(err)
+			}
+			sink.MaxSize(10)
+			tt.configure(sink)
+			now := time.Now()
+			writeAt(t, sink, now.Add(-11*day), "line")
+			// every line rotates the previous one into a backup named after its own time
+			ages := map[string]string{}
+			for _, age := range []int{10, 5, 1} {
+				at := now.Add(-time.Duration(age) * day)
+				ages[at.Format("20060102")] = strconv.Itoa(age) + "d"
+				writeAt(t, sink, at, "line")
+			}
+			if err := sink.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err := sink.Cleanup(); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, name := range logFiles(t, dir) {
+				if name == "app.log" {
+					continue
+				}
+				age := ages[strings.TrimPrefix(name, "app-")[:8]]
+				if strings.HasSuffix(name, ".gz") {
+					age += ".gz"
+				}
+				got = append(got, age)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.wantAges, " ") {
+				t.Errorf("backups %v, want %v", got, tt.wantAges)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package go_logger
+
+import "regexp"
+
+// Filter adds a filter to the logger. Events for which a filter returns false are dropped before they
+// are encoded or counted by the rate limit. Filters are inherited by child loggers.
+func (logger *Logger) Filter(filter func(*Event) bool) *Logger {
+	logger = logger.derive()
+	logger.filters = append(logger.filters[:len(logger.filters):len(logger.filters)], filter)
+	return logger
+}
+
+func (logger *Logger) accepts(event *Event) bool {
+	for _, filter := range logger.filters {
+		if !filter(event) {
+			countDropped(dropFiltered)
+			logger.stats.filtered.Add(1)
+			return false
+		}
+	}
+	return true
+}
+
+// DropMessages returns a filter dropping events whose message matches pattern.
+func DropMessages(pattern *regexp.Regexp) func(*Event) bool {
+	return func(event *Event) bool {
+		return !pattern.MatchString(event.Message)
+	}
+}
+
+// DropGoroutines returns a filter dropping events logged by the given goroutines.
+func DropGoroutines(goroutines ...string) func(*Event) bool {
+	return func(event *Event) bool {
+		for _, goroutine := range goroutines {
+			if event.GoroutineId == goroutine {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// DropField returns a filter dropping events carrying a field key with the given text value,
+// e.g. DropField("path", "/health").
+func DropField(key, value string) func(*Event) bool {
+	return func(event *Event) bool {
+		for _, field := range event.Fields {
+			if field.Key == key && field.text() == value {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,66 @@
+package go_logger_test
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters []func(*golog.Event) bool
+		want    []string
+	}{
+		{name: "none", want: []string{"GET /health", "GET /items", "query"}},
+		{
+			name:    "messages",
+			filters: []func(*golog.Event) bool{golog.DropMessages(regexp.MustCompile(`^GET /health`))},
+			want:    []string{"GET /items", "query"},
+		},
+		{
+			name:    "field",
+			filters: []func(*golog.Event) bool{golog.DropField("path", "/items")},
+			want:    []string{"GET /health", "query"},
+		},
+		{
+			name: "all must pass",
+			filters: []func(*golog.Event) bool{
+				golog.DropMessages(regexp.MustCompile(`health`)),
+				func(event *golog.Event) bool { return event.Message != "query" },
+			},
+			want: []string{"GET /items"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, observer := logtest.NewObservedLogger(golog.INFO)
+			for _, filter := range tt.filters {
+				logger = logger.Filter(filter)
+			}
+			logger.With(golog.String("path", "/health")).Info("GET /health")
+			// children inherit the filters
+			logger.Named("http").With(golog.String("path", "/items")).Info("GET /items")
+			logger.Info("query")
+			var got []string
+			for _, event := range observer.All() {
+				got = append(got, event.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDropGoroutines(t *testing.T) {
+	filter := golog.DropGoroutines("ticker", "poller")
+	for goroutine, want := range map[string]bool{"ticker": false, "poller": false, "main": true, "": true} {
+		if got := filter(&golog.Event{GoroutineId: goroutine}); got != want {
+			t.Errorf("goroutine %q passes %v, want %v", goroutine, got, want)
+		}
+	}
+}
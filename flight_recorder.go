@@ -0,0 +1,123 @@
+package go_logger
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// FlightRecorder keeps the last events of a logger in a ring buffer, including those below the level
+// of the logger, so that the context of a failure can be written out after the fact. Attach it with
+// Logger.Record; events at or above the level of the recorder are then created even when the logger
+// would discard them, which costs the allocations of enabled events.
+type FlightRecorder struct {
+	mutex     sync.Mutex
+	events    []Event
+	next      int
+	size      int
+	level     Level
+	encoder   Encoder
+	dumpLevel Level
+	dumpOut   io.Writer
+}
+
+// NewFlightRecorder returns a recorder of the last size events at all levels, dumped as JSON.
+func NewFlightRecorder(size int) *FlightRecorder {
+	return &FlightRecorder{events: make([]Event, max(size, 1)), level: TRACE, encoder: defaultJSONEncoder, dumpLevel: levelOff}
+}
+
+// Level sets the minimum level of recorded events. Configure the recorder before attaching it.
+func (recorder *FlightRecorder) Level(level Level) *FlightRecorder {
+	recorder.level = level
+	return recorder
+}
+
+// Encoder sets the encoder of Dump.
+func (recorder *FlightRecorder) Encoder(encoder Encoder) *FlightRecorder {
+	recorder.encoder = encoder
+	return recorder
+}
+
+// DumpAt dumps the buffer to out whenever an event at or above level is recorded, and empties it,
+// so that the next dump only contains what happened since.
+func (recorder *FlightRecorder) DumpAt(level Level, out io.Writer) *FlightRecorder {
+	recorder.dumpLevel = level
+	recorder.dumpOut = out
+	return recorder
+}
+
+// Record attaches a flight recorder to the logger; nil detaches it.
+func (logger *Logger) Record(recorder *FlightRecorder) *Logger {
+	logger = logger.derive()
+	logger.recorder = recorder
+	return logger
+}
+
+// record copies event into the ring, reusing the fields of the slot it replaces.
+func (recorder *FlightRecorder) record(event *Event) {
+	if event.Level < recorder.level {
+		return
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	slot := &recorder.events[recorder.next]
+	fields := append(slot.Fields[:0], event.Fields...)
+	*slot = *event
+	slot.Fields = fields
+	recorder.next = (recorder.next + 1) % len(recorder.events)
+	recorder.size = min(recorder.size+1, len(recorder.events))
+	if event.Level >= recorder.dumpLevel && recorder.dumpOut != nil {
+		_ = recorder.dump(recorder.dumpOut)
+		recorder.reset()
+	}
+}
+
+// Events returns copies of the recorded events, oldest first.
+func (recorder *FlightRecorder) Events() []Event {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	events := make([]Event, 0, recorder.size)
+	recorder.each(func(event *Event) {
+		events = append(events, *event.Clone())
+	})
+	return events
+}
+
+// Dump writes the recorded events, oldest first, to out. The buffer is kept.
+func (recorder *FlightRecorder) Dump(out io.Writer) error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.dump(out)
+}
+
+// Reset empties the buffer.
+func (recorder *FlightRecorder) Reset() {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.reset()
+}
+
+func (recorder *FlightRecorder) dump(out io.Writer) error {
+	sb := strings.Builder{}
+	recorder.each(func(event *Event) {
+		recorder.encoder.Encode(&sb, event)
+	})
+	return writeFull(out, sb.String())
+}
+
+func (recorder *FlightRecorder) reset() {
+	for i := range recorder.events {
+		recorder.events[i] = Event{Fields: recorder.events[i].Fields[:0]}
+	}
+	recorder.next, recorder.size = 0, 0
+}
+
+func (recorder *FlightRecorder) each(fn func(event *Event)) {
+	start := recorder.next - recorder.size
+	if start < 0 {
+		start += len(recorder.events)
+	}
+	for i := 0; i < recorder.size; i++ {
+		fn(&recorder.events[(start+i)%len(recorder.events)])
+	}
+}
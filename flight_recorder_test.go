@@ -0,0 +1,77 @@
+package go_logger_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func recorded(recorder *golog.FlightRecorder) []string {
+	var messages []string
+	for _, event := range recorder.Events() {
+		messages = append(messages, event.Message)
+	}
+	return messages
+}
+
+func TestFlightRecorder(t *testing.T) {
+	tests := []struct {
+		name         string
+		size         int
+		level        golog.Level
+		wantRecorded []string
+	}{
+		{name: "all levels", size: 10, level: golog.TRACE, wantRecorded: []string{"debug", "info", "warn", "info 2"}},
+		{name: "ring", size: 2, level: golog.TRACE, wantRecorded: []string{"warn", "info 2"}},
+		{name: "level", size: 10, level: golog.INFO, wantRecorded: []string{"info", "warn", "info 2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := golog.NewFlightRecorder(tt.size).Level(tt.level)
+			logger, observer := logtest.NewObservedLogger(golog.WARN)
+			logger = logger.Record(recorder)
+			logger.Debug("debug")
+			logger.Info("info")
+			logger.Warn("warn")
+			logger.Info("info 2")
+			if got := recorded(recorder); !slices.Equal(got, tt.wantRecorded) {
+				t.Errorf("recorded %q, want %q", got, tt.wantRecorded)
+			}
+			if observer.Len() != 1 {
+				t.Errorf("logged %d events, want only the WARN", observer.Len())
+			}
+			dump := bytes.Buffer{}
+			if err := recorder.Dump(&dump); err != nil {
+				t.Fatal(err)
+			}
+			if lines := strings.Count(dump.String(), "\n"); lines != len(tt.wantRecorded) {
+				t.Errorf("dumped %d lines, want %d", lines, len(tt.wantRecorded))
+			}
+			recorder.Reset()
+			if got := recorder.Events(); len(got) != 0 {
+				t.Errorf("%d events after Reset", len(got))
+			}
+		})
+	}
+}
+
+func TestFlightRecorderDumpAt(t *testing.T) {
+	dump := bytes.Buffer{}
+	recorder := golog.NewFlightRecorder(10).Encoder(golog.MustPatternEncoder("%l %m")).DumpAt(golog.ERROR, &dump)
+	logger, _ := logtest.NewObservedLogger(golog.ERROR)
+	logger = logger.Record(recorder)
+	logger.Debug("connecting")
+	logger.Info("retrying")
+	logger.Error("failed")
+	logger.Info("after")
+	if want := "DEBUG connecting\nINFO retrying\nERROR failed\n"; dump.String() != want {
+		t.Errorf("dumped %q, want %q", dump.String(), want)
+	}
+	if got := recorded(recorder); !slices.Equal(got, []string{"after"}) {
+		t.Errorf("recorded %q after the dump, want only the later event", got)
+	}
+}
@@ -0,0 +1,197 @@
+package go_logger
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+var ErrFluentAck = errors.New("go_logger: fluentd ack mismatch")
+
+// FluentSink ships events to fluentd or fluent-bit using the forward protocol in message mode.
+// The tag is the logger name, prefixed with the configured tag prefix. With acks enabled every
+// message waits for the acknowledgement of the server and is resent once on a new connection if
+// it does not arrive in time.
+type FluentSink struct {
+	mutex      sync.Mutex
+	network    string
+	address    string
+	conn       net.Conn
+	reader     *bufio.Reader
+	tagPrefix  string
+	ack        bool
+	ackTimeout time.Duration
+	level      Level
+	closed     bool
+}
+
+func NewFluentSink(network, address string) (*FluentSink, error) {
+	sink := &FluentSink{network: network, address: address, ackTimeout: 5 * time.Second, level: TRACE}
+	if err := sink.connect(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+func (sink *FluentSink) TagPrefix(prefix string) *FluentSink {
+	sink.tagPrefix = prefix
+	return sink
+}
+
+func (sink *FluentSink) Ack(ack bool, timeout time.Duration) *FluentSink {
+	sink.ack = ack
+	if timeout > 0 {
+		sink.ackTimeout = timeout
+	}
+	return sink
+}
+
+func (sink *FluentSink) Level(level Level) *FluentSink {
+	sink.level = level
+	return sink
+}
+
+func (sink *FluentSink) connect() error {
+	conn, err := net.Dial(sink.network, sink.address)
+	if err != nil {
+		return err
+	}
+	sink.conn = conn
+	sink.reader = bufio.NewReader(conn)
+	return nil
+}
+
+func (sink *FluentSink) tag(event *Event) string {
+	switch {
+	case sink.tagPrefix == "":
+		if event.Logger == "" {
+			return "go_logger"
+		}
+		return event.Logger
+	case event.Logger == "":
+		return sink.tagPrefix
+	default:
+		return sink.tagPrefix + "." + event.Logger
+	}
+}
+
+func (sink *FluentSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	var chunk string
+	if sink.ack {
+		var id [16]byte
+		if _, err := rand.Read(id[:]); err != nil {
+			return err
+		}
+		chunk = base64.StdEncoding.EncodeToString(id[:])
+	}
+	message := sink.encode(event, chunk)
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.closed {
+		return ErrSinkClosed
+	}
+	err := sink.send(message, chunk)
+	if err != nil {
+		// reconnect once, the aggregator may have been restarted
+		if sink.conn != nil {
+			_ = sink.conn.Close()
+			sink.conn = nil
+		}
+		err = sink.send(message, chunk)
+	}
+	return err
+}
+
+func (sink *FluentSink) send(message []byte, chunk string) error {
+	if sink.conn == nil {
+		if err := sink.connect(); err != nil {
+			return err
+		}
+	}
+	if _, err := sink.conn.Write(message); err != nil {
+		return err
+	}
+	if chunk == "" {
+		return nil
+	}
+	_ = sink.conn.SetReadDeadline(time.Now().Add(sink.ackTimeout))
+	response, err := readMsgpackStringMap(sink.reader)
+	_ = sink.conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		return err
+	}
+	if response["ack"] != chunk {
+		return ErrFluentAck
+	}
+	return nil
+}
+
+func (sink *FluentSink) encode(event *Event, chunk string) []byte {
+	buf := make([]byte, 0, 256)
+	buf = appendMsgpackArrayHeader(buf, 4)
+	buf = appendMsgpackString(buf, sink.tag(event))
+	// EventTime, extension type 0 with seconds and nanoseconds
+	buf = append(buf, 0xd7, 0x00)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(event.Timestamp.Unix()))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(event.Timestamp.Nanosecond()))
+	buf = appendEventRecord(buf, event)
+	if chunk == "" {
+		return appendMsgpackMapHeader(buf, 0)
+	}
+	buf = appendMsgpackMapHeader(buf, 1)
+	buf = appendMsgpackString(buf, "chunk")
+	return appendMsgpackString(buf, chunk)
+}
+
+// appendEventRecord writes the event as a msgpack map with the standard entries followed by the fields.
+func appendEventRecord(buf []byte, event *Event) []byte {
+	n := 3 + len(event.Fields)
+	if event.GoroutineId != "" {
+		n++
+	}
+	if event.Err != nil {
+		n++
+	}
+	if event.Caller.Defined() {
+		n++
+	}
+	buf = appendMsgpackMapHeader(buf, n)
+	buf = appendMsgpackString(appendMsgpackString(buf, "level"), event.Level.Long())
+	buf = appendMsgpackString(appendMsgpackString(buf, "logger"), event.Logger)
+	if event.GoroutineId != "" {
+		buf = appendMsgpackString(appendMsgpackString(buf, "goroutineId"), event.GoroutineId)
+	}
+	buf = appendMsgpackString(appendMsgpackString(buf, "message"), event.Message)
+	if event.Err != nil {
+		buf = appendMsgpackString(appendMsgpackString(buf, "error"), event.Err.Error())
+	}
+	if event.Caller.Defined() {
+		buf = appendMsgpackString(appendMsgpackString(buf, "caller"), event.Caller.String())
+	}
+	for _, field := range event.Fields {
+		buf = field.appendMsgpack(appendMsgpackString(buf, field.Key))
+	}
+	return buf
+}
+
+func (sink *FluentSink) Flush() error { return nil }
+
+func (sink *FluentSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.closed = true
+	if sink.conn == nil {
+		return nil
+	}
+	err := sink.conn.Close()
+	sink.conn = nil
+	return err
+}
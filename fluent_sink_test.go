@@ -0,0 +1,270 @@
+package go_logger_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// readMsgpack decodes the msgpack subset written by the fluent sink into strings, int64s, float64s,
+// bools, slices, maps and time.Time for EventTime.
+func readMsgpack(r *bufio.Reader) (any, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	readN := func(n int) ([]byte, error) {
+		buf := make([]byte, n)
+		_, err := io.ReadFull(r, buf)
+		return buf, err
+	}
+	readUint := func(n int) (uint64, error) {
+		buf, err := readN(n)
+		if err != nil {
+			return 0, err
+		}
+		var v uint64
+		for _, c := range buf {
+			v = v<<8 | uint64(c)
+		}
+		return v, nil
+	}
+	readString := func(n int) (any, error) {
+		buf, err := readN(n)
+		return string(buf), err
+	}
+	readArray := func(n int) (any, error) {
+		values := make([]any, n)
+		for i := range values {
+			if values[i], err = readMsgpack(r); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	readMap := func(n int) (any, error) {
+		values := make(map[string]any, n)
+		for i := 0; i < n; i++ {
+			key, err := readMsgpack(r)
+			if err != nil {
+				return nil, err
+			}
+			if values[fmt.Sprint(key)], err = readMsgpack(r); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b&0xe0 == 0xa0:
+		return readString(int(b & 0x1f))
+	case b&0xf0 == 0x90:
+		return readArray(int(b & 0x0f))
+	case b&0xf0 == 0x80:
+		return readMap(int(b & 0x0f))
+	}
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2, 0xc3:
+		return b == 0xc3, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := readUint(1 << (b - 0xcc))
+		return int64(v), err
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (b - 0xd0)
+		v, err := readUint(size)
+		return int64(v<<(64-8*size)) >> (64 - 8*size), err
+	case 0xcb:
+		v, err := readUint(8)
+		return math.Float64frombits(v), err
+	case 0xd9, 0xda, 0xdb:
+		n, err := readUint(1 << (b - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return readString(int(n))
+	case 0xdc, 0xdd:
+		n, err := readUint(2 << (b - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return readArray(int(n))
+	case 0xde, 0xdf:
+		n, err := readUint(2 << (b - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return readMap(int(n))
+	case 0xd7:
+		buf, err := readN(9)
+		if err != nil {
+			return nil, err
+		}
+		if buf[0] != 0 {
+			return nil, fmt.Errorf("unsupported extension type %d", buf[0])
+		}
+		return time.Unix(int64(binary.BigEndian.Uint32(buf[1:5])), int64(binary.BigEndian.Uint32(buf[5:]))).UTC(), nil
+	}
+	return nil, fmt.Errorf("unsupported msgpack type %#x", b)
+}
+
+// fluentServer accepts forward protocol messages and answers acks with ack, or the chunk if empty.
+func fluentServer(t *testing.T, ack string) (string, <-chan []any) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	messages := make(chan []any, 8)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					value, err := readMsgpack(reader)
+					if err != nil {
+						return
+					}
+					message, _ := value.([]any)
+					messages <- message
+					options, _ := message[len(message)-1].(map[string]any)
+					chunk, ok := options["chunk"].(string)
+					if !ok {
+						continue
+					}
+					if ack != "" {
+						chunk = ack
+					}
+					response := append([]byte{0x81, 0xa3, 'a', 'c', 'k', 0xd9, byte(len(chunk))}, chunk...)
+					if _, err := conn.Write(response); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String(), messages
+}
+
+func TestFluentSink(t *testing.T) {
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	tests := []struct {
+		name    string
+		prefix  string
+		logger  string
+		wantTag string
+	}{
+		{name: "logger name", logger: "api", wantTag: "api"},
+		{name: "prefixed", prefix: "app", logger: "api", wantTag: "app.api"},
+		{name: "prefix only", prefix: "app", wantTag: "app"},
+		{name: "default tag", wantTag: "go_logger"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, messages := fluentServer(t, "")
+			sink, err := golog.NewFluentSink("tcp", address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sink.Close()
+			sink.TagPrefix(tt.prefix)
+			event := &golog.Event{
+				Timestamp: timestamp, Level: golog.WARN, Logger: tt.logger, Message: "slow",
+				Err: errors.New("timeout"), Fields: []golog.Field{golog.Int("ms", 1500), golog.String("table", "users")},
+			}
+			if err := sink.Write(event); err != nil {
+				t.Fatal(err)
+			}
+			var message []any
+			select {
+			case message = <-messages:
+			case <-time.After(5 * time.Second):
+				t.Fatal("no message received")
+			}
+			if len(message) != 4 {
+				t.Fatalf("message %v, want tag, time, record and options", message)
+			}
+			if message[0] != tt.wantTag {
+				t.Errorf("tag %v, want %s", message[0], tt.wantTag)
+			}
+			if message[1] != timestamp {
+				t.Errorf("time %v, want %v", message[1], timestamp)
+			}
+			record, _ := message[2].(map[string]any)
+			want := map[string]any{
+				"level": "WARN", "logger": tt.logger, "message": "slow", "error": "timeout",
+				"ms": int64(1500), "table": "users",
+			}
+			for key, value := range want {
+				if record[key] != value {
+					t.Errorf("record %s is %v, want %v", key, record[key], value)
+				}
+			}
+			if len(record) != len(want) {
+				t.Errorf("record %v, want %v", record, want)
+			}
+			if options, _ := message[3].(map[string]any); len(options) != 0 {
+				t.Errorf("options %v, want none without acks", options)
+			}
+		})
+	}
+}
+
+func TestFluentSinkAck(t *testing.T) {
+	tests := []struct {
+		name     string
+		ack      string
+		wantErr  error
+		wantSent int
+	}{
+		{name: "acknowledged", wantSent: 1},
+		{name: "wrong ack resent once", ack: "bogus", wantErr: golog.ErrFluentAck, wantSent: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, messages := fluentServer(t, tt.ack)
+			sink, err := golog.NewFluentSink("tcp", address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sink.Close()
+			sink.Ack(true, time.Second)
+			if err := sink.Write(&golog.Event{Level: golog.INFO, Message: "m"}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Write = %v, want %v", err, tt.wantErr)
+			}
+			var chunks []string
+			for len(chunks) < tt.wantSent {
+				select {
+				case message := <-messages:
+					options, _ := message[3].(map[string]any)
+					chunk, _ := options["chunk"].(string)
+					chunks = append(chunks, chunk)
+				case <-time.After(5 * time.Second):
+					t.Fatalf("received %d messages, want %d", len(chunks), tt.wantSent)
+				}
+			}
+			if chunks[0] == "" || chunks[len(chunks)-1] != chunks[0] {
+				t.Errorf("chunks %q, want one non-empty chunk id per event", chunks)
+			}
+		})
+	}
+}
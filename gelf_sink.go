@@ -0,0 +1,246 @@
+package go_logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type GELFCompression int
+
+const (
+	GELFNoCompression GELFCompression = iota
+	GELFGzip
+	GELFZlib
+)
+
+const (
+	gelfMaxChunks = 128
+	gelfChunkHead = 12
+)
+
+var ErrGELFTooLarge = errors.New("go_logger: GELF message exceeds 128 chunks")
+
+// GELFSink sends events in the Graylog Extended Log Format. Over UDP messages are optionally compressed
+// and split into chunks, over TCP they are sent uncompressed and terminated by a null byte. Logger name,
+// goroutine, error, caller and fields become additional fields.
+type GELFSink struct {
+	mutex       sync.Mutex
+	network     string
+	address     string
+	conn        net.Conn
+	host        string
+	compression GELFCompression
+	chunkSize   int
+	level       Level
+	closed      bool
+}
+
+func NewGELFSink(network, address string) (*GELFSink, error) {
+	if !strings.HasPrefix(network, "udp") && !strings.HasPrefix(network, "tcp") {
+		return nil, errors.New("go_logger: GELF supports udp and tcp only")
+	}
+	host, _ := os.Hostname()
+	sink := &GELFSink{network: network, address: address, host: host, chunkSize: 1420, level: TRACE}
+	if strings.HasPrefix(network, "udp") {
+		sink.compression = GELFGzip
+	}
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+	sink.conn = conn
+	return sink, nil
+}
+
+func (sink *GELFSink) Host(host string) *GELFSink {
+	sink.host = host
+	return sink
+}
+
+// Compression sets the compression of UDP messages. It is ignored for TCP.
+func (sink *GELFSink) Compression(compression GELFCompression) *GELFSink {
+	sink.compression = compression
+	return sink
+}
+
+// ChunkSize sets the maximum datagram size for UDP, including the chunk header.
+func (sink *GELFSink) ChunkSize(size int) *GELFSink {
+	if size > gelfChunkHead {
+		sink.chunkSize = size
+	}
+	return sink
+}
+func (sink *GELFSink) Level(level Level) *GELFSink {
+	sink.level = level
+	return sink
+}
+
+func (sink *GELFSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	message := sink.encode(event)
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.closed {
+		return ErrSinkClosed
+	}
+	if sink.conn == nil {
+		conn, err := net.Dial(sink.network, sink.address)
+		if err != nil {
+			return err
+		}
+		sink.conn = conn
+	}
+	var err error
+	if strings.HasPrefix(sink.network, "tcp") {
+		_, err = sink.conn.Write(append(message, 0))
+	} else {
+		err = sink.writeUDP(message)
+	}
+	if err != nil && strings.HasPrefix(sink.network, "tcp") {
+		_ = sink.conn.Close()
+		sink.conn = nil
+	}
+	return err
+}
+
+func (sink *GELFSink) writeUDP(message []byte) error {
+	var err error
+	if message, err = gelfCompress(message, sink.compression); err != nil {
+		return err
+	}
+	if len(message) <= sink.chunkSize {
+		_, err = sink.conn.Write(message)
+		return err
+	}
+	payload := sink.chunkSize - gelfChunkHead
+	count := (len(message) + payload - 1) / payload
+	if count > gelfMaxChunks {
+		return ErrGELFTooLarge
+	}
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return err
+	}
+	chunk := make([]byte, 0, sink.chunkSize)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * payload
+		if end > len(message) {
+			end = len(message)
+		}
+		chunk = append(chunk[:0], 0x1e, 0x0f)
+		chunk = append(chunk, id[:]...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, message[i*payload:end]...)
+		if _, err := sink.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func gelfCompress(message []byte, compression GELFCompression) ([]byte, error) {
+	var buf bytes.Buffer
+	var w interface {
+		Write([]byte) (int, error)
+		Close() error
+	}
+	switch compression {
+	case GELFGzip:
+		w = gzip.NewWriter(&buf)
+	case GELFZlib:
+		w = zlib.NewWriter(&buf)
+	default:
+		return message, nil
+	}
+	if _, err := w.Write(message); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (sink *GELFSink) encode(event *Event) []byte {
+	sb := buffer{}
+	sb.WriteString(`{"version":"1.1","host":`)
+	writeJSONString(&sb, sink.host)
+	sb.WriteString(`,"short_message":`)
+	writeJSONString(&sb, event.Message)
+	if event.Err != nil {
+		sb.WriteString(`,"full_message":`)
+		writeJSONString(&sb, event.Message+": "+event.Err.Error())
+	}
+	sb.WriteString(`,"timestamp":`)
+	sb.WriteString(strconv.FormatFloat(float64(event.Timestamp.UnixMicro())/1e6, 'f', 6, 64))
+	sb.WriteString(`,"level":`)
+	sb.WriteString(strconv.Itoa(SyslogSeverity(event.Level)))
+	first := false
+	writeJSONKey(&sb, "_logger", &first)
+	writeJSONString(&sb, event.Logger)
+	if event.GoroutineId != "" {
+		writeJSONKey(&sb, "_goroutine", &first)
+		writeJSONString(&sb, event.GoroutineId)
+	}
+	if event.Err != nil {
+		writeJSONKey(&sb, "_error", &first)
+		writeJSONString(&sb, event.Err.Error())
+	}
+	if event.Caller.Defined() {
+		writeJSONKey(&sb, "_file", &first)
+		writeJSONString(&sb, event.Caller.File)
+		writeJSONKey(&sb, "_line", &first)
+		sb.WriteString(strconv.Itoa(event.Caller.Line))
+	}
+	for _, field := range event.Fields {
+		writeJSONKey(&sb, gelfFieldName(field.Key), &first)
+		if field.Type == ObjectType || field.Type == ArrayType {
+			value := buffer{}
+			field.writeJSON(&value)
+			writeJSONString(&sb, value.String())
+		} else {
+			field.writeJSON(&sb)
+		}
+	}
+	sb.WriteByte('}')
+	return []byte(sb.String())
+}
+
+// gelfFieldName prefixes key with an underscore and replaces characters not allowed by GELF.
+// The reserved field "_id" becomes "_id_".
+func gelfFieldName(key string) string {
+	name := "_" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, key)
+	if name == "_id" {
+		return "_id_"
+	}
+	return name
+}
+
+func (sink *GELFSink) Flush() error { return nil }
+
+func (sink *GELFSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.closed = true
+	if sink.conn == nil {
+		return nil
+	}
+	err := sink.conn.Close()
+	sink.conn = nil
+	return err
+}
@@ -0,0 +1,218 @@
+package go_logger_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func gelfEvent(msg string) *golog.Event {
+	return &golog.Event{
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 250000000, time.UTC),
+		Level:     golog.ERROR,
+		Logger:    "api",
+		Message:   msg,
+		Err:       errors.New("timeout"),
+		Fields: []golog.Field{
+			golog.Int("status", 504),
+			golog.String("id", "r1"),
+			golog.String("user name", "alice"),
+			golog.Object("db", golog.String("table", "users")),
+		},
+	}
+}
+
+func listenUDP(t *testing.T) net.PacketConn {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+func readDatagram(t *testing.T, conn net.PacketConn) []byte {
+	t.Helper()
+	packet := make([]byte, 65536)
+	n, _, err := conn.ReadFrom(packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return packet[:n]
+}
+
+func checkGELFMessage(t *testing.T, message []byte, wantShort string) {
+	t.Helper()
+	var got map[string]any
+	if err := json.Unmarshal(message, &got); err != nil {
+		t.Fatalf("%v in %q", err, message)
+	}
+	want := map[string]any{
+		"version":       "1.1",
+		"host":          "web-1",
+		"short_message": wantShort,
+		"full_message":  wantShort + ": timeout",
+		"timestamp":     1704164645.25,
+		"level":         3.0,
+		"_logger":       "api",
+		"_error":        "timeout",
+		"_status":       504.0,
+		"_id_":          "r1",
+		"_user_name":    "alice",
+		"_db":           `{"table":"users"}`,
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s is %v, want %v", key, got[key], value)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("message %v, want %v", got, want)
+	}
+}
+
+func TestGELFSinkUDP(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression golog.GELFCompression
+		decompress  func(io.Reader) (io.Reader, error)
+	}{
+		{name: "uncompressed", compression: golog.GELFNoCompression},
+		{
+			name:        "gzip",
+			compression: golog.GELFGzip,
+			decompress:  func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		},
+		{
+			name:        "zlib",
+			compression: golog.GELFZlib,
+			decompress:  func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := listenUDP(t)
+			sink, err := golog.NewGELFSink("udp", conn.LocalAddr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sink.Close()
+			sink.Host("web-1").Compression(tt.compression)
+			if err := sink.Write(gelfEvent("request failed")); err != nil {
+				t.Fatal(err)
+			}
+			message := readDatagram(t, conn)
+			if tt.decompress != nil {
+				r, err := tt.decompress(bytes.NewReader(message))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if message, err = io.ReadAll(r); err != nil {
+					t.Fatal(err)
+				}
+			}
+			checkGELFMessage(t, message, "request failed")
+		})
+	}
+}
+
+func TestGELFSinkChunking(t *testing.T) {
+	conn := listenUDP(t)
+	sink, err := golog.NewGELFSink("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	sink.Host("web-1").Compression(golog.GELFNoCompression).ChunkSize(112)
+	long := strings.Repeat("x", 1000)
+	if err := sink.Write(gelfEvent(long)); err != nil {
+		t.Fatal(err)
+	}
+	var message []byte
+	var id []byte
+	for seq, count := 0, 1; seq < count; seq++ {
+		chunk := readDatagram(t, conn)
+		if len(chunk) > 112 || len(chunk) <= 12 || chunk[0] != 0x1e || chunk[1] != 0x0f {
+			t.Fatalf("chunk %d has %d bytes and header % x", seq, len(chunk), chunk[:min(len(chunk), 12)])
+		}
+		if seq == 0 {
+			id, count = chunk[2:10], int(chunk[11])
+		} else if !bytes.Equal(chunk[2:10], id) || int(chunk[11]) != count {
+			t.Fatalf("chunk %d has id % x of %d, want % x of %d", seq, chunk[2:10], chunk[11], id, count)
+		}
+		if int(chunk[10]) != seq {
+			t.Fatalf("chunk %d has sequence number %d", seq, chunk[10])
+		}
+		message = append(message, chunk[12:]...)
+	}
+	checkGELFMessage(t, message, long)
+}
+
+func TestGELFSinkTooLarge(t *testing.T) {
+	conn := listenUDP(t)
+	sink, err := golog.NewGELFSink("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	sink.Compression(golog.GELFNoCompression).ChunkSize(13)
+	if err := sink.Write(gelfEvent("too long")); !errors.Is(err, golog.ErrGELFTooLarge) {
+		t.Errorf("Write = %v, want ErrGELFTooLarge", err)
+	}
+}
+
+func TestGELFSinkTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	received := make(chan []byte, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			message, err := reader.ReadBytes(0)
+			if err != nil {
+				return
+			}
+			received <- message
+		}
+	}()
+	sink, err := golog.NewGELFSink("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	sink.Host("web-1").Compression(golog.GELFGzip)
+	for _, msg := range []string{"first", "second"} {
+		if err := sink.Write(gelfEvent(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, msg := range []string{"first", "second"} {
+		select {
+		case message := <-received:
+			// TCP ignores compression and terminates every message with a null byte
+			checkGELFMessage(t, bytes.TrimSuffix(message, []byte{0}), msg)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("message %q not received", msg)
+		}
+	}
+}
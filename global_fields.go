@@ -0,0 +1,36 @@
+package go_logger
+
+import "sync/atomic"
+
+var globalFields atomic.Pointer[[]Field]
+
+// SetGlobalFields sets fields added to the events of every logger in the process, e.g. environment,
+// region or service name. They are written before the fields of the logger and the event. Calling it
+// again replaces the fields, calling it without fields removes them.
+func SetGlobalFields(fields ...Field) {
+	if len(fields) == 0 {
+		globalFields.Store(nil)
+		return
+	}
+	fields = append([]Field(nil), fields...)
+	globalFields.Store(&fields)
+}
+
+// GlobalFields returns the fields set by SetGlobalFields.
+func GlobalFields() []Field {
+	if fields := globalFields.Load(); fields != nil {
+		return append([]Field(nil), *fields...)
+	}
+	return nil
+}
+
+// addGlobalFields puts the global fields in front of fields.
+func addGlobalFields(fields []Field) []Field {
+	global := globalFields.Load()
+	if global == nil {
+		return fields
+	}
+	merged := make([]Field, 0, len(*global)+len(fields))
+	merged = append(merged, *global...)
+	return append(merged, fields...)
+}
@@ -0,0 +1,30 @@
+package go_logger
+
+import (
+	"runtime"
+	"sync"
+)
+
+// stackBufferPool keeps the buffer handed to runtime.Stack, which escapes, from being allocated per event.
+var stackBufferPool = sync.Pool{New: func() any { return new([64]byte) }}
+
+// goroutineIdFromStack parses the id from the header of runtime.Stack, "goroutine 42 [running]:".
+// This walks the whole stack and dominates the cost of an event; build with the tag go_logger_fastgoid
+// on amd64 or arm64 to read the id from the runtime instead.
+func goroutineIdFromStack() int {
+	buf := stackBufferPool.Get().(*[64]byte)
+	defer stackBufferPool.Put(buf)
+	n := runtime.Stack(buf[:], false)
+	const prefix = "goroutine "
+	if n <= len(prefix) || string(buf[:len(prefix)]) != prefix {
+		return -1
+	}
+	id := 0
+	for _, c := range buf[len(prefix):n] {
+		if c < '0' || c > '9' {
+			break
+		}
+		id = id*10 + int(c-'0')
+	}
+	return id
+}
@@ -0,0 +1,46 @@
+//go:build go_logger_fastgoid && (amd64 || arm64)
+
+package go_logger
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// getg returns the runtime's g of the calling goroutine, implemented in assembly.
+func getg() unsafe.Pointer
+
+// goidOffset is the offset of the goid field within g, or -1 if it could not be determined and ids are
+// parsed from the stack.
+var goidOffset = findGoidOffset()
+
+// findGoidOffset searches g for the id reported by runtime.Stack and verifies the candidate on a second
+// goroutine, so that changes of the runtime's layout fall back to the slow path instead of failing.
+func findGoidOffset() int {
+	id := int64(goroutineIdFromStack())
+	g := getg()
+	for offset := 0; offset < 512; offset += 8 {
+		if *(*int64)(unsafe.Add(g, offset)) != id {
+			continue
+		}
+		var matches bool
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			matches = *(*int64)(unsafe.Add(getg(), offset)) == int64(goroutineIdFromStack())
+		}()
+		wg.Wait()
+		if matches {
+			return offset
+		}
+	}
+	return -1
+}
+
+func goroutineId() int {
+	if goidOffset < 0 {
+		return goroutineIdFromStack()
+	}
+	return int(*(*int64)(unsafe.Add(getg(), goidOffset)))
+}
@@ -0,0 +1,7 @@
+//go:build !go_logger_fastgoid || !(amd64 || arm64)
+
+package go_logger
+
+func goroutineId() int {
+	return goroutineIdFromStack()
+}
@@ -0,0 +1,18 @@
+module github.com/jeschu/go-logger/gormlogger
+
+go 1.21
+
+require (
+	github.com/jeschu/go-logger v0.0.0
+	gorm.io/gorm v1.31.2
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	golang.org/x/sys v0.16.0 // indirect
+	golang.org/x/term v0.16.0 // indirect
+	golang.org/x/text v0.20.0 // indirect
+)
+
+replace github.com/jeschu/go-logger => ../
@@ -0,0 +1,81 @@
+// Package gormlogger implements the logger interface of gorm with go-logger. It is a separate module,
+// so that go-logger itself does not depend on gorm.
+package gormlogger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Logger passes the queries traced by gorm to a QueryLogger and its messages to the underlying logger.
+type Logger struct {
+	queries              *golog.QueryLogger
+	mode                 gormlogger.LogLevel
+	ignoreRecordNotFound bool
+}
+
+var _ gormlogger.Interface = (*Logger)(nil)
+
+// New returns a gorm logger in mode gormlogger.Info, so that the level of the QueryLogger decides.
+func New(queries *golog.QueryLogger) *Logger {
+	return &Logger{queries: queries, mode: gormlogger.Info}
+}
+
+// IgnoreRecordNotFound logs queries failing with gorm.ErrRecordNotFound as successful.
+func (l *Logger) IgnoreRecordNotFound(ignore bool) *Logger {
+	l.ignoreRecordNotFound = ignore
+	return l
+}
+
+// LogMode returns a copy logging only errors, errors and slow queries, or everything.
+func (l *Logger) LogMode(mode gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.mode = mode
+	return &copied
+}
+
+func (l *Logger) Info(ctx context.Context, msg string, args ...any) {
+	if l.mode >= gormlogger.Info {
+		l.logger(ctx).Infof(msg, args...)
+	}
+}
+
+func (l *Logger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.mode >= gormlogger.Warn {
+		l.logger(ctx).Warnf(msg, args...)
+	}
+}
+
+func (l *Logger) Error(ctx context.Context, msg string, args ...any) {
+	if l.mode >= gormlogger.Error {
+		l.logger(ctx).Errorf(msg, args...)
+	}
+}
+
+func (l *Logger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.mode <= gormlogger.Silent {
+		return
+	}
+	if err != nil && l.ignoreRecordNotFound && errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	duration := time.Since(begin)
+	if err == nil && l.mode < gormlogger.Info {
+		// in the modes Error and Warn only failed and slow queries are logged
+		if l.mode < gormlogger.Warn || !l.queries.Slow(duration) {
+			return
+		}
+	}
+	query, rows := fc()
+	l.queries.Log(ctx, query, duration, rows, err)
+}
+
+// logger returns the logger of the QueryLogger with the fields of ctx.
+func (l *Logger) logger(ctx context.Context) *golog.Logger {
+	return golog.FromContext(golog.ContextWithLogger(ctx, l.queries.Logger()))
+}
@@ -0,0 +1,36 @@
+package gormlogger_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/gormlogger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestCaller(t *testing.T) {
+	logger, observer := logtest.NewObservedLogger(golog.TRACE)
+	gorm := gormlogger.New(golog.NewQueryLogger(logger.WithCaller(true)))
+	tests := []struct {
+		name string
+		log  func()
+	}{
+		{"info", func() { gorm.Info(context.Background(), "connected to %s", "db") }},
+		{"warn", func() { gorm.Warn(context.Background(), "pool exhausted") }},
+		{"error", func() { gorm.Error(context.Background(), "connection lost") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.log()
+			events := observer.TakeAll()
+			if len(events) != 1 {
+				t.Fatalf("%d events, want 1", len(events))
+			}
+			if caller := events[0].Caller; !strings.HasSuffix(caller.File, "logger_test.go") {
+				t.Errorf("caller is %s, want the test", caller)
+			}
+		})
+	}
+}
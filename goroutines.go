@@ -0,0 +1,127 @@
+package go_logger
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var goroutineSeq atomic.Uint64
+
+// Go runs fn in a new goroutine registered under name for its lifetime, including when fn panics. If the
+// calling goroutine has a name, the new one inherits it with name as suffix, "parent/name"; an empty name
+// then becomes a sequence number.
+func Go(name string, fn func()) {
+	goRoutineNamesMutex.RLock()
+	parent, named := goRoutineNames[goroutineId()]
+	goRoutineNamesMutex.RUnlock()
+	if named {
+		if name == "" {
+			name = strconv.FormatUint(goroutineSeq.Add(1), 10)
+		}
+		name = parent + "/" + name
+	}
+	go func() {
+		if name != "" {
+			defer SetGoroutineName(name)()
+		}
+		fn()
+	}()
+}
+
+// GoroutineNames returns a copy of the registry of goroutine names by id.
+func GoroutineNames() map[int]string {
+	goRoutineNamesMutex.RLock()
+	defer goRoutineNamesMutex.RUnlock()
+	names := make(map[int]string, len(goRoutineNames))
+	for id, name := range goRoutineNames {
+		names[id] = name
+	}
+	return names
+}
+
+// CurrentGoroutineName returns the name the calling goroutine is logged with, its id if it has none.
+func CurrentGoroutineName() string {
+	return goroutineName(goroutineId())
+}
+
+// SweepGoroutineNames removes names and goroutine fields of goroutines that have exited without
+// removing them and returns the number of removed entries. It collects the stacks of all goroutines,
+// which stops the world briefly.
+func SweepGoroutineNames() int {
+	live, maxId := liveGoroutines()
+	// ids are never reused, ids above maxId belong to goroutines started after the dump
+	stale := func(id int) bool { return id <= maxId && !live[id] }
+	removed := 0
+	goRoutineNamesMutex.Lock()
+	for id := range goRoutineNames {
+		if stale(id) {
+			delete(goRoutineNames, id)
+			removed++
+		}
+	}
+	goRoutineNamesMutex.Unlock()
+	mdcMutex.Lock()
+	for id := range mdcFields {
+		if stale(id) {
+			delete(mdcFields, id)
+			mdcSize.Add(-1)
+			removed++
+		}
+	}
+	mdcMutex.Unlock()
+	return removed
+}
+
+// SweepGoroutineNamesEvery runs SweepGoroutineNames every interval until the returned function is called.
+func SweepGoroutineNamesEvery(interval time.Duration) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				SweepGoroutineNames()
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// liveGoroutines returns the ids of all goroutines from the headers "goroutine N [...]:" of a full dump
+// and the highest of them.
+func liveGoroutines() (map[int]bool, int) {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	live := make(map[int]bool)
+	maxId := 0
+	for _, line := range bytes.Split(buf, []byte("\n")) {
+		rest, ok := bytes.CutPrefix(line, []byte("goroutine "))
+		if !ok {
+			continue
+		}
+		end := bytes.IndexByte(rest, ' ')
+		if end < 0 {
+			continue
+		}
+		if id, err := strconv.Atoi(string(rest[:end])); err == nil {
+			live[id] = true
+			maxId = max(maxId, id)
+		}
+	}
+	return live, maxId
+}
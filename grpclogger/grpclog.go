@@ -0,0 +1,59 @@
+package grpclogger
+
+import (
+	"fmt"
+
+	golog "github.com/jeschu/go-logger"
+	"google.golang.org/grpc/grpclog"
+)
+
+// LoggerV2 implements grpclog.LoggerV2. Install it with grpclog.SetLoggerV2(NewLoggerV2(logger)).
+type LoggerV2 struct {
+	logger    *golog.Logger
+	verbosity int
+}
+
+var _ grpclog.LoggerV2 = (*LoggerV2)(nil)
+
+func NewLoggerV2(logger *golog.Logger) *LoggerV2 {
+	return &LoggerV2{logger: logger}
+}
+
+// Verbosity sets the level up to which V reports true, 0 by default.
+func (l *LoggerV2) Verbosity(verbosity int) *LoggerV2 {
+	l.verbosity = verbosity
+	return l
+}
+
+func (l *LoggerV2) Info(args ...any)                    { l.logger.Info(fmt.Sprint(args...)) }
+func (l *LoggerV2) Infoln(args ...any)                  { l.logger.Info(sprintln(args)) }
+func (l *LoggerV2) Infof(format string, args ...any)    { l.logger.Infof(format, args...) }
+func (l *LoggerV2) Warning(args ...any)                 { l.logger.Warn(fmt.Sprint(args...)) }
+func (l *LoggerV2) Warningln(args ...any)               { l.logger.Warn(sprintln(args)) }
+func (l *LoggerV2) Warningf(format string, args ...any) { l.logger.Warnf(format, args...) }
+func (l *LoggerV2) Error(args ...any)                   { l.logger.Error(fmt.Sprint(args...)) }
+func (l *LoggerV2) Errorln(args ...any)                 { l.logger.Error(sprintln(args)) }
+func (l *LoggerV2) Errorf(format string, args ...any)   { l.logger.Errorf(format, args...) }
+
+// Fatal logs at FATAL and exits with status 1 after running the exit hooks, as required by grpclog.
+func (l *LoggerV2) Fatal(args ...any) {
+	l.logger.Fatal(fmt.Sprint(args...))
+	golog.Exit(1)
+}
+func (l *LoggerV2) Fatalln(args ...any) {
+	l.logger.Fatal(sprintln(args))
+	golog.Exit(1)
+}
+func (l *LoggerV2) Fatalf(format string, args ...any) {
+	l.logger.Fatalf(format, args...)
+	golog.Exit(1)
+}
+
+func (l *LoggerV2) V(level int) bool {
+	return level <= l.verbosity
+}
+
+func sprintln(args []any) string {
+	s := fmt.Sprintln(args...)
+	return s[:len(s)-1]
+}
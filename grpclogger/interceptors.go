@@ -0,0 +1,246 @@
+// Package grpclogger provides gRPC interceptors logging through go-logger. It is a separate module,
+// so that go-logger itself does not depend on gRPC.
+package grpclogger
+
+import (
+	"context"
+	"io"
+	"path"
+	"slices"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Interceptors log one event per RPC with method, code, duration and peer. The level is taken from a
+// per-method override or derived from the status code. With payload logging enabled, every request
+// and response message is logged at TRACE.
+type Interceptors struct {
+	logger       *golog.Logger
+	codeLevel    func(codes.Code) golog.Level
+	methodLevels map[string]golog.Level
+	payloads     bool
+}
+
+func New(logger *golog.Logger) *Interceptors {
+	return &Interceptors{logger: logger, codeLevel: DefaultCodeLevel, methodLevels: map[string]golog.Level{}}
+}
+
+// DefaultCodeLevel logs server side failures as ERROR, client side failures as WARN and everything else as INFO.
+func DefaultCodeLevel(code codes.Code) golog.Level {
+	switch code {
+	case codes.OK:
+		return golog.INFO
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.Unauthenticated, codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return golog.WARN
+	default:
+		return golog.ERROR
+	}
+}
+
+func (interceptors *Interceptors) CodeLevel(codeLevel func(codes.Code) golog.Level) *Interceptors {
+	interceptors.codeLevel = codeLevel
+	return interceptors
+}
+
+// MethodLevel logs calls of fullMethod, e.g. "/grpc.health.v1.Health/Check", at level regardless of the code.
+func (interceptors *Interceptors) MethodLevel(fullMethod string, level golog.Level) *Interceptors {
+	interceptors.methodLevels[fullMethod] = level
+	return interceptors
+}
+
+// LogPayloads logs request and response messages at TRACE.
+func (interceptors *Interceptors) LogPayloads(payloads bool) *Interceptors {
+	interceptors.payloads = payloads
+	return interceptors
+}
+
+func (interceptors *Interceptors) UnaryServer() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		logger := interceptors.callLogger(ctx, "server", info.FullMethod)
+		interceptors.logPayload(logger, "request", req)
+		resp, err := handler(golog.ContextWithLogger(ctx, logger), req)
+		if err == nil {
+			interceptors.logPayload(logger, "response", resp)
+		}
+		interceptors.logCall(logger, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func (interceptors *Interceptors) StreamServer() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		logger := interceptors.callLogger(stream.Context(), "server", info.FullMethod)
+		err := handler(srv, &serverStream{
+			ServerStream: stream,
+			ctx:          golog.ContextWithLogger(stream.Context(), logger),
+			interceptors: interceptors,
+			logger:       logger,
+		})
+		interceptors.logCall(logger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (interceptors *Interceptors) UnaryClient() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		logger := interceptors.logger.With(fields(ctx, "client", method)...).With(golog.String("peer", cc.Target()))
+		interceptors.logPayload(logger, "request", req)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			interceptors.logPayload(logger, "response", reply)
+		}
+		interceptors.logCall(logger, method, start, err)
+		return err
+	}
+}
+
+// StreamClient logs a stream when it ends, i.e. when RecvMsg returns an error or io.EOF.
+func (interceptors *Interceptors) StreamClient() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		logger := interceptors.logger.With(fields(ctx, "client", method)...).With(golog.String("peer", cc.Target()))
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			interceptors.logCall(logger, method, start, err)
+			return nil, err
+		}
+		return &clientStream{ClientStream: stream, interceptors: interceptors, logger: logger, method: method, start: start}, nil
+	}
+}
+
+func (interceptors *Interceptors) callLogger(ctx context.Context, kind, method string) *golog.Logger {
+	logger := interceptors.logger.With(fields(ctx, kind, method)...)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		logger = logger.With(golog.String("peer", p.Addr.String()))
+	}
+	return logger
+}
+
+func fields(ctx context.Context, kind, method string) []golog.Field {
+	service, name := path.Split(method)
+	contextFields := golog.ContextFields(ctx)
+	result := append(contextFields[:len(contextFields):len(contextFields)],
+		golog.String("grpc.kind", kind),
+		golog.String("grpc.service", path.Clean(service)[1:]),
+		golog.String("grpc.method", name))
+	if span, ok := golog.SpanFromContext(ctx); ok {
+		// a trace_id or span_id already among the context fields is not added again
+		if !hasField(contextFields, golog.TraceIDKey) {
+			result = append(result, golog.String(golog.TraceIDKey, span.TraceID))
+		}
+		if !hasField(contextFields, golog.SpanIDKey) {
+			result = append(result, golog.String(golog.SpanIDKey, span.SpanID))
+		}
+	}
+	return result
+}
+
+func hasField(fields []golog.Field, key string) bool {
+	return slices.ContainsFunc(fields, func(field golog.Field) bool { return field.Key == key })
+}
+
+func (interceptors *Interceptors) logCall(logger *golog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level, ok := interceptors.methodLevels[method]
+	if !ok {
+		level = interceptors.codeLevel(code)
+	}
+	eventAt(logger, level).
+		Str("grpc.code", code.String()).
+		Dur("duration", time.Since(start)).
+		Err(err).
+		Msg("finished call " + method)
+}
+
+func (interceptors *Interceptors) logPayload(logger *golog.Logger, direction string, message any) {
+	if interceptors.payloads && logger.IsTrace() {
+		logger.TraceEvent().Any("payload", message).Msg(direction)
+	}
+}
+
+func eventAt(logger *golog.Logger, level golog.Level) *golog.EventBuilder {
+	switch level {
+	case golog.TRACE:
+		return logger.TraceEvent()
+	case golog.DEBUG:
+		return logger.DebugEvent()
+	case golog.INFO:
+		return logger.InfoEvent()
+	case golog.WARN:
+		return logger.WarnEvent()
+	case golog.ERROR:
+		return logger.ErrorEvent()
+	default:
+		return logger.FatalEvent()
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx          context.Context
+	interceptors *Interceptors
+	logger       *golog.Logger
+}
+
+func (stream *serverStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *serverStream) SendMsg(m any) error {
+	err := stream.ServerStream.SendMsg(m)
+	if err == nil {
+		stream.interceptors.logPayload(stream.logger, "response", m)
+	}
+	return err
+}
+
+func (stream *serverStream) RecvMsg(m any) error {
+	err := stream.ServerStream.RecvMsg(m)
+	if err == nil {
+		stream.interceptors.logPayload(stream.logger, "request", m)
+	}
+	return err
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	interceptors *Interceptors
+	logger       *golog.Logger
+	method       string
+	start        time.Time
+	done         bool
+}
+
+func (stream *clientStream) SendMsg(m any) error {
+	err := stream.ClientStream.SendMsg(m)
+	if err == nil {
+		stream.interceptors.logPayload(stream.logger, "request", m)
+	}
+	return err
+}
+
+func (stream *clientStream) RecvMsg(m any) error {
+	err := stream.ClientStream.RecvMsg(m)
+	if err == nil {
+		stream.interceptors.logPayload(stream.logger, "response", m)
+		return nil
+	}
+	if !stream.done {
+		stream.done = true
+		callErr := err
+		if err == io.EOF {
+			callErr = nil
+		}
+		stream.interceptors.logCall(stream.logger, stream.method, stream.start, callErr)
+	}
+	return err
+}
@@ -0,0 +1,34 @@
+package go_logger
+
+// PreEncode adds a hook called for every enabled event before redaction and encoding. Hooks run in the
+// order they were added and may modify the event, e.g. add or remove fields. Appending to event.Fields
+// never changes the fields of the logger.
+func (logger *Logger) PreEncode(hook func(*Event)) *Logger {
+	logger = logger.derive()
+	logger.preEncodeHooks = append(logger.preEncodeHooks[:len(logger.preEncodeHooks):len(logger.preEncodeHooks)], hook)
+	return logger
+}
+
+// PostWrite adds a hook called after an event was written to the output or all sinks without error.
+// The event must not be modified or retained after the hook returns.
+func (logger *Logger) PostWrite(hook func(*Event)) *Logger {
+	logger = logger.derive()
+	logger.postWriteHooks = append(logger.postWriteHooks[:len(logger.postWriteHooks):len(logger.postWriteHooks)], hook)
+	return logger
+}
+
+func (logger *Logger) runPreEncodeHooks(event *Event) {
+	if len(logger.preEncodeHooks) == 0 {
+		return
+	}
+	event.Fields = event.Fields[:len(event.Fields):len(event.Fields)]
+	for _, hook := range logger.preEncodeHooks {
+		hook(event)
+	}
+}
+
+func (logger *Logger) runPostWriteHooks(event *Event) {
+	for _, hook := range logger.postWriteHooks {
+		hook(event)
+	}
+}
@@ -0,0 +1,47 @@
+package go_logger_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestHooks(t *testing.T) {
+	var calls []string
+	logger, observer := logtest.NewObservedLogger(golog.INFO)
+	logger = logger.With(golog.String("service", "api")).
+		PreEncode(func(event *golog.Event) {
+			calls = append(calls, "pre 1 "+event.Message)
+			event.Fields = append(event.Fields, golog.String("host", "web-1"))
+		}).
+		PreEncode(func(event *golog.Event) { calls = append(calls, "pre 2 "+event.Message) }).
+		PostWrite(func(event *golog.Event) { calls = append(calls, "post "+event.Message) })
+	logger.Debug("disabled")
+	logger.Info("enabled")
+	want := []string{"pre 1 enabled", "pre 2 enabled", "post enabled"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("hooks ran %q, want %q", calls, want)
+	}
+	if observer.FilterField(golog.String("host", "web-1")).Len() != 1 {
+		t.Errorf("events %v, want the field added by the hook", observer.All())
+	}
+	// the hook appended to the event, not to the fields of the logger
+	logger.PreEncode(func(event *golog.Event) {
+		if len(event.Fields) != 2 {
+			t.Errorf("fields %v, want service and host only", event.Fields)
+		}
+	}).Info("again")
+}
+
+func TestPostWriteSkippedOnError(t *testing.T) {
+	written := 0
+	logger := golog.NewLogger("").Sinks(&flakySink{err: errors.New("disk full")}).
+		PostWrite(func(*golog.Event) { written++ })
+	logger.Info("lost")
+	if written != 0 {
+		t.Errorf("post-write hook ran %d times for a failed write, want 0", written)
+	}
+}
@@ -0,0 +1,162 @@
+package go_logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPSink posts batches of events as NDJSON to an HTTP endpoint. Failed requests are retried with
+// exponential backoff on network errors, 429 and 5xx responses; other responses fail the batch.
+type HTTPSink struct {
+	url        string
+	client     *http.Client
+	header     http.Header
+	encoder    Encoder
+	level      Level
+	compress   bool
+	maxRetries int
+	backoff    time.Duration
+	batcher    *batcher
+	onError    func(error, []*Event)
+}
+
+func NewHTTPSink(url string, batchSize int, interval time.Duration) *HTTPSink {
+	sink := &HTTPSink{
+		url:        url,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		header:     http.Header{},
+		encoder:    NewJSONEncoder(),
+		level:      TRACE,
+		maxRetries: 3,
+		backoff:    500 * time.Millisecond,
+	}
+	sink.batcher = newBatcher(batchSize, interval, sink.send)
+	sink.batcher.onError = func(err error, events []*Event) {
+		if sink.onError != nil {
+			sink.onError(err, events)
+		}
+	}
+	return sink
+}
+
+func (sink *HTTPSink) Client(client *http.Client) *HTTPSink {
+	sink.client = client
+	return sink
+}
+func (sink *HTTPSink) Header(key, value string) *HTTPSink {
+	sink.header.Set(key, value)
+	return sink
+}
+func (sink *HTTPSink) Encoder(encoder Encoder) *HTTPSink {
+	sink.encoder = encoder
+	return sink
+}
+func (sink *HTTPSink) Level(level Level) *HTTPSink {
+	sink.level = level
+	return sink
+}
+
+// Compress gzips request bodies and sets Content-Encoding accordingly.
+func (sink *HTTPSink) Compress(compress bool) *HTTPSink {
+	sink.compress = compress
+	return sink
+}
+func (sink *HTTPSink) Retry(maxRetries int, backoff time.Duration) *HTTPSink {
+	sink.maxRetries = maxRetries
+	sink.backoff = backoff
+	return sink
+}
+
+// OnError is called with batches that could not be delivered after all retries, and with ErrBatchDropped
+// for batches dropped because sending could not keep up.
+func (sink *HTTPSink) OnError(callback func(error, []*Event)) *HTTPSink {
+	sink.onError = callback
+	return sink
+}
+
+func (sink *HTTPSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	return sink.batcher.add(event)
+}
+
+func (sink *HTTPSink) send(events []*Event) error {
+	body, err := sink.body(events)
+	if err != nil {
+		return err
+	}
+	backoff := sink.backoff
+	for attempt := 0; ; attempt++ {
+		err = sink.post(body)
+		if err == nil || !isRetryable(err) || attempt >= sink.maxRetries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (sink *HTTPSink) body(events []*Event) ([]byte, error) {
+	sb := strings.Builder{}
+	for _, event := range events {
+		sink.encoder.Encode(&sb, event)
+	}
+	if !sink.compress {
+		return []byte(sb.String()), nil
+	}
+	buf := bytes.Buffer{}
+	gz := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(gz, sb.String()); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (sink *HTTPSink) post(body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, sink.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range sink.header {
+		request.Header[key] = values
+	}
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	if sink.compress {
+		request.Header.Set("Content-Encoding", "gzip")
+	}
+	response, err := sink.client.Do(request)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+	if response.StatusCode >= 300 {
+		return statusError(response.StatusCode)
+	}
+	return nil
+}
+
+// statusError is an unexpected HTTP response status.
+type statusError int
+
+func (status statusError) Error() string {
+	return "go_logger: request failed with status " + strconv.Itoa(int(status))
+}
+
+// isRetryable reports whether a request failing with err may succeed when repeated.
+func isRetryable(err error) bool {
+	status, ok := err.(statusError)
+	return !ok || status == http.StatusTooManyRequests || status >= 500
+}
+
+func (sink *HTTPSink) Flush() error { return sink.batcher.flush() }
+func (sink *HTTPSink) Close() error { return sink.batcher.close() }
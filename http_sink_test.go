@@ -0,0 +1,104 @@
+package go_logger_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestHTTPSink(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		compress     bool
+		wantAttempts int
+		wantErr      bool
+	}{
+		{name: "delivered", statuses: []int{200}, wantAttempts: 1},
+		{name: "compressed", statuses: []int{204}, compress: true, wantAttempts: 1},
+		{name: "retried on 5xx", statuses: []int{503, 502, 200}, wantAttempts: 3},
+		{name: "retried on 429", statuses: []int{429, 200}, wantAttempts: 2},
+		{name: "retries exhausted", statuses: []int{500, 500, 500}, wantAttempts: 3, wantErr: true},
+		{name: "not retried on 4xx", statuses: []int{400, 200}, wantAttempts: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mutex sync.Mutex
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body io.Reader = r.Body
+				if r.Header.Get("Content-Encoding") == "gzip" {
+					gz, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					body = gz
+				} else if tt.compress {
+					t.Error("compressed body without Content-Encoding gzip")
+				}
+				if got := r.Header.Get("Content-Type"); got != "application/x-ndjson" {
+					t.Errorf("Content-Type %q, want application/x-ndjson", got)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer t" {
+					t.Errorf("Authorization %q, want Bearer t", got)
+				}
+				data, _ := io.ReadAll(body)
+				mutex.Lock()
+				bodies = append(bodies, string(data))
+				status := tt.statuses[min(len(bodies), len(tt.statuses))-1]
+				mutex.Unlock()
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+			var failed error
+			sink := golog.NewHTTPSink(server.URL, 2, 0).
+				Header("Authorization", "Bearer t").
+				Compress(tt.compress).
+				Retry(2, time.Millisecond).
+				OnError(func(err error, events []*golog.Event) { failed = err })
+			_ = sink.Write(&golog.Event{Level: golog.INFO, Message: "a"})
+			_ = sink.Write(&golog.Event{Level: golog.INFO, Message: "b"})
+			err := sink.Close()
+			if (err != nil || failed != nil) != tt.wantErr {
+				t.Errorf("Close = %v, OnError got %v, want error %v", err, failed, tt.wantErr)
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			if len(bodies) != tt.wantAttempts {
+				t.Fatalf("%d requests, want %d", len(bodies), tt.wantAttempts)
+			}
+			var messages []string
+			for _, line := range strings.Split(strings.TrimSuffix(bodies[0], "\n"), "\n") {
+				_, msg, _ := strings.Cut(line, `"message":"`)
+				msg, _, _ = strings.Cut(msg, `"`)
+				messages = append(messages, msg)
+			}
+			if !slices.Equal(messages, []string{"a", "b"}) {
+				t.Errorf("body %q, want one NDJSON line for a and b", bodies[0])
+			}
+		})
+	}
+}
+
+func TestHTTPSinkUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	var failed []*golog.Event
+	sink := golog.NewHTTPSink(url, 1, 0).Retry(1, time.Millisecond).
+		OnError(func(err error, events []*golog.Event) { failed = events })
+	_ = sink.Write(&golog.Event{Level: golog.INFO, Message: "lost"})
+	_ = sink.Close()
+	if len(failed) != 1 || failed[0].Message != "lost" {
+		t.Errorf("OnError got %v, want the lost event", failed)
+	}
+}
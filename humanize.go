@@ -0,0 +1,111 @@
+package go_logger
+
+import (
+	"strconv"
+	"time"
+)
+
+// humanizer marks integer fields written in a human-friendly way by PLAIN output. JSON and the binary
+// encoders write the plain number.
+type humanizer uint8
+
+const (
+	humanDuration humanizer = iota + 1
+	humanBytes
+	humanCount
+)
+
+// HumanDuration returns a field written like "1.2s" or "3h25m" in PLAIN output and as nanoseconds in JSON.
+func HumanDuration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: IntType, Integer: int64(value), Interface: humanDuration}
+}
+
+// Bytes returns a field written with binary units like "1.4 MiB" in PLAIN output and as bytes in JSON.
+func Bytes(key string, value int64) Field {
+	return Field{Key: key, Type: IntType, Integer: value, Interface: humanBytes}
+}
+
+// Count returns a field written with thousands separators like "1,234,567" in PLAIN output and as
+// number in JSON.
+func Count(key string, value int64) Field {
+	return Field{Key: key, Type: IntType, Integer: value, Interface: humanCount}
+}
+
+func (h humanizer) write(sb *buffer, value int64) {
+	switch h {
+	case humanDuration:
+		writeHumanDuration(sb, time.Duration(value))
+	case humanBytes:
+		writeHumanBytes(sb, value)
+	case humanCount:
+		writeHumanCount(sb, value)
+	}
+}
+
+func writeHumanDuration(sb *buffer, d time.Duration) {
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	var buf [32]byte
+	switch {
+	case d < time.Microsecond:
+		sb.WriteString(strconv.FormatInt(int64(d), 10) + "ns")
+	case d < time.Millisecond:
+		sb.Write(strconv.AppendFloat(buf[:0], float64(d)/1e3, 'f', 1, 64))
+		sb.WriteString("µs")
+	case d < time.Second:
+		sb.Write(strconv.AppendFloat(buf[:0], float64(d)/1e6, 'f', 1, 64))
+		sb.WriteString("ms")
+	case d < time.Minute:
+		sb.Write(strconv.AppendFloat(buf[:0], d.Seconds(), 'f', 1, 64))
+		sb.WriteByte('s')
+	case d < time.Hour:
+		d = d.Round(time.Second)
+		sb.WriteString(strconv.Itoa(int(d/time.Minute)) + "m" + strconv.Itoa(int(d%time.Minute/time.Second)) + "s")
+	case d < 24*time.Hour:
+		d = d.Round(time.Minute)
+		sb.WriteString(strconv.Itoa(int(d/time.Hour)) + "h" + strconv.Itoa(int(d%time.Hour/time.Minute)) + "m")
+	default:
+		d = d.Round(time.Hour)
+		sb.WriteString(strconv.Itoa(int(d/(24*time.Hour))) + "d" + strconv.Itoa(int(d%(24*time.Hour)/time.Hour)) + "h")
+	}
+}
+
+func writeHumanBytes(sb *buffer, n int64) {
+	const units = "KMGTPE"
+	value, negative := uint64(n), n < 0
+	if negative {
+		sb.WriteByte('-')
+		value = uint64(-n)
+	}
+	if value < 1024 {
+		sb.WriteString(strconv.FormatUint(value, 10) + " B")
+		return
+	}
+	f, unit := float64(value)/1024, 0
+	for f >= 1024 && unit < len(units)-1 {
+		f /= 1024
+		unit++
+	}
+	var buf [32]byte
+	sb.Write(strconv.AppendFloat(buf[:0], f, 'f', 1, 64))
+	sb.WriteByte(' ')
+	sb.WriteByte(units[unit])
+	sb.WriteString("iB")
+}
+
+func writeHumanCount(sb *buffer, n int64) {
+	var buf [32]byte
+	digits := strconv.AppendInt(buf[:0], n, 10)
+	if n < 0 {
+		sb.WriteByte('-')
+		digits = digits[1:]
+	}
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteByte(digit)
+	}
+}
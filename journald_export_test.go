@@ -0,0 +1,10 @@
+//go:build linux
+
+package go_logger
+
+import "net"
+
+// SetSocket points sink at a test socket instead of the systemd journal.
+func (sink *JournaldSink) SetSocket(path string) {
+	sink.addr = &net.UnixAddr{Name: path, Net: "unixgram"}
+}
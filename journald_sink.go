@@ -0,0 +1,176 @@
+//go:build linux
+
+package go_logger
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+const journalSocket = "/run/systemd/journal/socket"
+
+// JournaldSink writes events natively to the systemd journal, so that fields show up as journal
+// fields in `journalctl -o json`. Field keys are converted to upper case journal field names.
+type JournaldSink struct {
+	mutex      sync.Mutex
+	conn       *net.UnixConn
+	addr       *net.UnixAddr
+	identifier string
+	level      Level
+}
+
+func NewJournaldSink() (*JournaldSink, error) {
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	return &JournaldSink{
+		conn:       conn,
+		addr:       &net.UnixAddr{Name: journalSocket, Net: "unixgram"},
+		identifier: os.Args[0][strings.LastIndexByte(os.Args[0], '/')+1:],
+		level:      TRACE,
+	}, nil
+}
+
+// JournaldAvailable reports whether the journal socket exists.
+func JournaldAvailable() bool {
+	_, err := os.Stat(journalSocket)
+	return err == nil
+}
+
+func (sink *JournaldSink) Identifier(identifier string) *JournaldSink {
+	sink.identifier = identifier
+	return sink
+}
+func (sink *JournaldSink) Level(level Level) *JournaldSink {
+	sink.level = level
+	return sink
+}
+
+func (sink *JournaldSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	sb := buffer{}
+	message := event.Message
+	if event.Err != nil {
+		message += ": " + event.Err.Error()
+	}
+	writeJournalField(&sb, "MESSAGE", message)
+	writeJournalField(&sb, "PRIORITY", strconv.Itoa(SyslogSeverity(event.Level)))
+	writeJournalField(&sb, "SYSLOG_IDENTIFIER", sink.identifier)
+	writeJournalField(&sb, "LOGGER", event.Logger)
+	if event.GoroutineId != "" {
+		writeJournalField(&sb, "GOROUTINE", event.GoroutineId)
+	}
+	if event.Err != nil {
+		writeJournalField(&sb, "ERROR", event.Err.Error())
+	}
+	if event.Caller.Defined() {
+		writeJournalField(&sb, "CODE_FILE", event.Caller.File)
+		writeJournalField(&sb, "CODE_LINE", strconv.Itoa(event.Caller.Line))
+		writeJournalField(&sb, "CODE_FUNC", event.Caller.Function)
+	}
+	for _, field := range event.Fields {
+		value := buffer{}
+		field.writeText(&value)
+		writeJournalField(&sb, journalFieldName(field.Key), value.String())
+	}
+	data := []byte(sb.String())
+
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.conn == nil {
+		return ErrSinkClosed
+	}
+	_, err := sink.conn.WriteToUnix(data, sink.addr)
+	if err == nil || !isMessageTooLong(err) {
+		return err
+	}
+	return sink.writeViaFile(data)
+}
+
+// writeViaFile passes messages exceeding the datagram size in an unlinked temporary file, as
+// described by the journal native protocol.
+func (sink *JournaldSink) writeViaFile(data []byte) error {
+	file, err := os.CreateTemp("/dev/shm", "go-logger-journal-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	if err := os.Remove(file.Name()); err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	rights := syscall.UnixRights(int(file.Fd()))
+	_, _, err = sink.conn.WriteMsgUnix(nil, rights, sink.addr)
+	return err
+}
+
+func isMessageTooLong(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	return errors.Is(opErr.Err, syscall.EMSGSIZE) || errors.Is(opErr.Err, syscall.ENOBUFS)
+}
+
+func writeJournalField(sb *buffer, name, value string) {
+	sb.WriteString(name)
+	if strings.IndexByte(value, '\n') < 0 {
+		sb.WriteByte('=')
+		sb.WriteString(value)
+		sb.WriteByte('\n')
+		return
+	}
+	sb.WriteByte('\n')
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(value)))
+	sb.Write(size[:])
+	sb.WriteString(value)
+	sb.WriteByte('\n')
+}
+
+// journalFieldName converts a key into a valid journal field name: upper case letters, digits and
+// underscores, not starting with an underscore or digit.
+func journalFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	name = strings.TrimLeft(name, "_0123456789")
+	if name == "" {
+		return "FIELD"
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+func (sink *JournaldSink) Flush() error { return nil }
+
+func (sink *JournaldSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.conn == nil {
+		return nil
+	}
+	err := sink.conn.Close()
+	sink.conn = nil
+	return err
+}
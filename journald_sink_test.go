@@ -0,0 +1,155 @@
+//go:build linux
+
+package go_logger_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// parseJournal decodes the journal native protocol: NAME=value lines, or NAME, a little endian length
+// and the raw value for values containing newlines.
+func parseJournal(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	fields := map[string]string{}
+	for len(data) > 0 {
+		end := bytes.IndexAny(data, "=\n")
+		if end < 0 {
+			t.Fatalf("unterminated field %q", data)
+		}
+		name := string(data[:end])
+		if data[end] == '=' {
+			data = data[end+1:]
+			line := bytes.IndexByte(data, '\n')
+			fields[name] = string(data[:line])
+			data = data[line+1:]
+			continue
+		}
+		data = data[end+1:]
+		size := binary.LittleEndian.Uint64(data[:8])
+		fields[name] = string(data[8 : 8+size])
+		if data[8+size] != '\n' {
+			t.Fatalf("field %s is not terminated by a newline", name)
+		}
+		data = data[9+size:]
+	}
+	return fields
+}
+
+// journal listens on a unix datagram socket and returns it with a sink writing to it.
+func journal(t *testing.T) (*net.UnixConn, *golog.JournaldSink) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	sink, err := golog.NewJournaldSink()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sink.Close() })
+	sink.SetSocket(path)
+	return conn, sink.Identifier("app")
+}
+
+func TestJournaldSink(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *golog.Event
+		want  map[string]string
+	}{
+		{
+			name:  "message",
+			event: &golog.Event{Level: golog.INFO, Logger: "api", Message: "started"},
+			want:  map[string]string{"MESSAGE": "started", "PRIORITY": "6", "SYSLOG_IDENTIFIER": "app", "LOGGER": "api"},
+		},
+		{
+			name: "error and fields",
+			event: &golog.Event{
+				Level: golog.ERROR, Message: "failed", Err: errors.New("timeout"), GoroutineId: "main",
+				Fields: []golog.Field{golog.Int("status", 504), golog.String("request.id", "r1"), golog.String("_9x", "y")},
+			},
+			want: map[string]string{
+				"MESSAGE": "failed: timeout", "PRIORITY": "3", "SYSLOG_IDENTIFIER": "app", "LOGGER": "",
+				"GOROUTINE": "main", "ERROR": "timeout", "STATUS": "504", "REQUEST_ID": "r1", "X": "y",
+			},
+		},
+		{
+			name:  "multi-line message",
+			event: &golog.Event{Level: golog.WARN, Message: "line 1\nline 2"},
+			want:  map[string]string{"MESSAGE": "line 1\nline 2", "PRIORITY": "4", "SYSLOG_IDENTIFIER": "app", "LOGGER": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, sink := journal(t)
+			if err := sink.Write(tt.event); err != nil {
+				t.Fatal(err)
+			}
+			packet := make([]byte, 65536)
+			n, err := conn.Read(packet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := parseJournal(t, packet[:n])
+			for name, value := range tt.want {
+				if got[name] != value {
+					t.Errorf("%s is %q, want %q", name, got[name], value)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("fields %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJournaldSinkLargeMessage(t *testing.T) {
+	if _, err := os.Stat("/dev/shm"); err != nil {
+		t.Skip("no /dev/shm")
+	}
+	conn, sink := journal(t)
+	message := strings.Repeat("x", 4<<20)
+	if err := sink.Write(&golog.Event{Level: golog.INFO, Message: message}); err != nil {
+		t.Fatal(err)
+	}
+	oob := make([]byte, syscall.CmsgSpace(4))
+	_, oobn, _, _, err := conn.ReadMsgUnix(nil, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("control messages %v, %v, want one with the file", messages, err)
+	}
+	fds, err := syscall.ParseUnixRights(&messages[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("file descriptors %v, %v, want one", fds, err)
+	}
+	file := os.NewFile(uintptr(fds[0]), "journal")
+	defer file.Close()
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parseJournal(t, data)["MESSAGE"]; got != message {
+		t.Errorf("MESSAGE has %d bytes, want %d", len(got), len(message))
+	}
+}
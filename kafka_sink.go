@@ -0,0 +1,94 @@
+package go_logger
+
+import (
+	"strings"
+	"time"
+)
+
+type KafkaMessage struct {
+	Topic string
+	Key   []byte
+	Value []byte
+	Time  time.Time
+}
+
+// KafkaProducer is implemented by a thin adapter around the Kafka client of the application
+// (sarama, franz-go, segmentio/kafka-go, ...), so this package does not depend on any of them.
+// SendMessages must return only once the batch has been delivered or has failed.
+type KafkaProducer interface {
+	SendMessages(messages []KafkaMessage) error
+}
+
+// KeyByLogger and KeyByGoroutine are key functions for KafkaSink.Key.
+func KeyByLogger(event *Event) []byte    { return []byte(event.Logger) }
+func KeyByGoroutine(event *Event) []byte { return []byte(event.GoroutineId) }
+
+// KafkaSink publishes encoded events to a topic. Events are batched until BatchSize events are
+// pending or the linger interval has passed; failed batches are reported to OnDeliveryFailure.
+type KafkaSink struct {
+	producer  KafkaProducer
+	topic     string
+	encoder   Encoder
+	key       func(*Event) []byte
+	level     Level
+	batcher   *batcher
+	onFailure func(error, []KafkaMessage)
+}
+
+func NewKafkaSink(producer KafkaProducer, topic string, batchSize int, linger time.Duration) *KafkaSink {
+	sink := &KafkaSink{producer: producer, topic: topic, encoder: NewJSONEncoder(), key: KeyByLogger, level: TRACE}
+	sink.batcher = newBatcher(batchSize, linger, sink.send)
+	return sink
+}
+
+func (sink *KafkaSink) Encoder(encoder Encoder) *KafkaSink {
+	sink.encoder = encoder
+	return sink
+}
+
+// Key sets the function selecting the message key, which determines the partition. nil sends messages without key.
+func (sink *KafkaSink) Key(key func(*Event) []byte) *KafkaSink {
+	sink.key = key
+	return sink
+}
+
+func (sink *KafkaSink) Level(level Level) *KafkaSink {
+	sink.level = level
+	return sink
+}
+
+func (sink *KafkaSink) OnDeliveryFailure(callback func(error, []KafkaMessage)) *KafkaSink {
+	sink.onFailure = callback
+	return sink
+}
+
+func (sink *KafkaSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	return sink.batcher.add(event)
+}
+
+func (sink *KafkaSink) send(events []*Event) error {
+	messages := make([]KafkaMessage, len(events))
+	for i, event := range events {
+		sb := strings.Builder{}
+		sink.encoder.Encode(&sb, event)
+		messages[i] = KafkaMessage{
+			Topic: sink.topic,
+			Value: []byte(strings.TrimSuffix(sb.String(), "\n")),
+			Time:  event.Timestamp,
+		}
+		if sink.key != nil {
+			messages[i].Key = sink.key(event)
+		}
+	}
+	err := sink.producer.SendMessages(messages)
+	if err != nil && sink.onFailure != nil {
+		sink.onFailure(err, messages)
+	}
+	return err
+}
+
+func (sink *KafkaSink) Flush() error { return sink.batcher.flush() }
+func (sink *KafkaSink) Close() error { return sink.batcher.close() }
@@ -0,0 +1,51 @@
+package go_logger
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// GoroutineLabel is the pprof label carrying the goroutine name set by Do.
+const GoroutineLabel = "goroutine"
+
+// Do runs fn with the goroutine named name: fn's context and every goroutine started from it carry the
+// pprof label GoroutineLabel, so the name shows up in profiles, and log lines of the calling goroutine
+// use the name like SetGoroutineName. Goroutines started by fn log under the name when they log with
+// the context, i.e. with the *Ctx methods. The previous name is restored afterwards.
+func Do(ctx context.Context, name string, fn func(ctx context.Context)) {
+	pprof.Do(ctx, pprof.Labels(GoroutineLabel, name), func(ctx context.Context) {
+		id := goroutineId()
+		goRoutineNamesMutex.Lock()
+		previous, named := goRoutineNames[id]
+		goRoutineNames[id] = name
+		goRoutineNamesMutex.Unlock()
+		defer func() {
+			goRoutineNamesMutex.Lock()
+			if named {
+				goRoutineNames[id] = previous
+			} else {
+				delete(goRoutineNames, id)
+			}
+			goRoutineNamesMutex.Unlock()
+		}()
+		fn(ctx)
+	})
+}
+
+// contextGoroutineName names the calling goroutine by its registered name, then by the pprof label of
+// ctx and last by its id.
+func contextGoroutineName(ctx context.Context) string {
+	id := goroutineId()
+	goRoutineNamesMutex.RLock()
+	name, ok := goRoutineNames[id]
+	goRoutineNamesMutex.RUnlock()
+	if ok {
+		return name
+	}
+	if ctx != nil {
+		if name, ok := pprof.Label(ctx, GoroutineLabel); ok {
+			return name
+		}
+	}
+	return goroutineName(id)
+}
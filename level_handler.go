@@ -0,0 +1,63 @@
+package go_logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+type levelEntry struct {
+	Logger string `json:"logger"`
+	Level  Level  `json:"level"`
+}
+
+// LevelHandler lists the default and all registered loggers with their levels on GET. On PUT or POST it
+// sets the level of a logger name or pattern as described at SetLevel, taken either from the query
+// parameters logger and level or from a JSON body {"logger":"http.*","level":"debug"}. An empty logger
+// name addresses the default logger.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			entry := levelEntry{Logger: r.URL.Query().Get("logger")}
+			if text := r.URL.Query().Get("level"); text != "" {
+				if err := entry.Level.UnmarshalText([]byte(text)); err != nil {
+					writeLevelError(w, http.StatusBadRequest, err)
+					return
+				}
+			} else if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+				writeLevelError(w, http.StatusBadRequest, err)
+				return
+			}
+			if entry.Logger == "" {
+				Default().SetLevel(entry.Level)
+			} else {
+				SetLevel(entry.Logger, entry.Level)
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			writeLevelError(w, http.StatusMethodNotAllowed, nil)
+			return
+		}
+		entries := []levelEntry{{Logger: "", Level: Default().GetLevel()}}
+		registry.mutex.Lock()
+		for name, logger := range registry.loggers {
+			entries = append(entries, levelEntry{Logger: name, Level: logger.GetLevel()})
+		}
+		registry.mutex.Unlock()
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Logger < entries[j].Logger })
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(entries)
+	})
+}
+
+func writeLevelError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	message := http.StatusText(status)
+	if err != nil {
+		message = err.Error()
+	}
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
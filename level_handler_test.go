@@ -0,0 +1,77 @@
+package go_logger_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestLevelHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		wantStatus  int
+		wantLevel   golog.Level
+		wantDefault golog.Level
+	}{
+		{name: "list", method: http.MethodGet, target: "/", wantStatus: http.StatusOK, wantLevel: golog.INFO, wantDefault: golog.INFO},
+		{name: "query", method: http.MethodPut, target: "/?logger=handler.api&level=debug", wantStatus: http.StatusOK, wantLevel: golog.DEBUG, wantDefault: golog.INFO},
+		{name: "json pattern", method: http.MethodPost, target: "/", body: `{"logger":"handler.*","level":"error"}`, wantStatus: http.StatusOK, wantLevel: golog.ERROR, wantDefault: golog.INFO},
+		{name: "default logger", method: http.MethodPut, target: "/?level=warn", wantStatus: http.StatusOK, wantLevel: golog.INFO, wantDefault: golog.WARN},
+		{name: "unknown level", method: http.MethodPut, target: "/?logger=handler.api&level=loud", wantStatus: http.StatusBadRequest, wantLevel: golog.INFO, wantDefault: golog.INFO},
+		{name: "malformed body", method: http.MethodPost, target: "/", body: "{", wantStatus: http.StatusBadRequest, wantLevel: golog.INFO, wantDefault: golog.INFO},
+		{name: "method not allowed", method: http.MethodDelete, target: "/", wantStatus: http.StatusMethodNotAllowed, wantLevel: golog.INFO, wantDefault: golog.INFO},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaultLevel := golog.Default().GetLevel()
+			defer golog.Default().SetLevel(defaultLevel)
+			defer golog.ResetLevels()
+			golog.Default().SetLevel(golog.INFO)
+			logger := golog.GetLogger("handler.api")
+			logger.SetLevel(golog.INFO)
+
+			response := httptest.NewRecorder()
+			golog.LevelHandler().ServeHTTP(response, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+			if response.Code != tt.wantStatus {
+				t.Fatalf("status %d, want %d: %s", response.Code, tt.wantStatus, response.Body)
+			}
+			if got := logger.GetLevel(); got != tt.wantLevel {
+				t.Errorf("level of handler.api is %v, want %v", got, tt.wantLevel)
+			}
+			if got := golog.Default().GetLevel(); got != tt.wantDefault {
+				t.Errorf("default level is %v, want %v", got, tt.wantDefault)
+			}
+			if tt.wantStatus != http.StatusOK {
+				var body map[string]string
+				if err := json.NewDecoder(response.Body).Decode(&body); err != nil || body["error"] == "" {
+					t.Errorf("body %v (%v), want an error message", body, err)
+				}
+				return
+			}
+			var entries []struct {
+				Logger string
+				Level  golog.Level
+			}
+			if err := json.NewDecoder(response.Body).Decode(&entries); err != nil {
+				t.Fatal(err)
+			}
+			listed := map[string]golog.Level{}
+			for _, entry := range entries {
+				listed[entry.Logger] = entry.Level
+			}
+			if level, ok := listed[""]; !ok || level != tt.wantDefault {
+				t.Errorf("listed default logger at %v, want %v", level, tt.wantDefault)
+			}
+			if level, ok := listed["handler.api"]; !ok || level != tt.wantLevel {
+				t.Errorf("listed handler.api at %v, want %v", level, tt.wantLevel)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package go_logger
+
+import "fmt"
+
+// LeveledLogger logs messages with alternating keys and values, as expected by the LeveledLogger
+// interface of hashicorp/go-retryablehttp and similar libraries.
+type LeveledLogger struct {
+	logger *Logger
+}
+
+func (logger *Logger) Leveled() *LeveledLogger {
+	return &LeveledLogger{logger: logger}
+}
+
+func (leveled *LeveledLogger) Error(msg string, keysAndValues ...any) {
+	leveled.log(ERROR, msg, keysAndValues)
+}
+func (leveled *LeveledLogger) Warn(msg string, keysAndValues ...any) {
+	leveled.log(WARN, msg, keysAndValues)
+}
+func (leveled *LeveledLogger) Info(msg string, keysAndValues ...any) {
+	leveled.log(INFO, msg, keysAndValues)
+}
+func (leveled *LeveledLogger) Debug(msg string, keysAndValues ...any) {
+	leveled.log(DEBUG, msg, keysAndValues)
+}
+
+func (leveled *LeveledLogger) log(level Level, msg string, keysAndValues []any) {
+	if !leveled.logger.enabled(level) {
+		return
+	}
+	event := createEvent(level, msg, nil)
+	if len(keysAndValues) > 0 {
+		fields := leveled.logger.fields
+		event.Fields = append(fields[:len(fields):len(fields)], keyValueFields(keysAndValues)...)
+	}
+	leveled.logger.log(event)
+}
+
+// keyValueFields converts alternating keys and values into fields. A value without key gets the key "!BADKEY".
+func keyValueFields(keysAndValues []any) []Field {
+	fields := make([]Field, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			fields = append(fields, Any("!BADKEY", keysAndValues[i]))
+			break
+		}
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		fields = append(fields, Any(key, keysAndValues[i+1]))
+	}
+	return fields
+}
@@ -0,0 +1,93 @@
+package go_logger
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// Flush writes everything buffered by the sinks of the logger, or syncs Out and ErrorOut if the logger
+// has no sinks.
+func (logger *Logger) Flush() error {
+	sinks := logger.sinks
+	if output := logger.configuredOutput(); output != nil {
+		defer output.release()
+		sinks = output.sinks
+	}
+	if len(sinks) == 0 {
+		if logger.errorOut != nil {
+			return errors.Join(flushOut(logger.out), flushOut(logger.errorOut))
+		}
+		return flushOut(logger.out)
+	}
+	var errs []error
+	for _, sink := range sinks {
+		errs = append(errs, sink.Flush())
+	}
+	return errors.Join(errs...)
+}
+
+func flushOut(out io.Writer) error {
+	switch out := out.(type) {
+	case interface{ Flush() error }:
+		return out.Flush()
+	case *os.File:
+		if out == os.Stdout || out == os.Stderr {
+			return nil
+		}
+		return out.Sync()
+	}
+	return nil
+}
+
+// Close flushes and closes all sinks of the logger. Out is flushed but not closed.
+func (logger *Logger) Close() error {
+	sinks := logger.sinks
+	if output := logger.configuredOutput(); output != nil {
+		defer output.release()
+		sinks = output.sinks
+	}
+	if len(sinks) == 0 {
+		return logger.Flush()
+	}
+	var errs []error
+	for _, sink := range sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
+
+var exitHooks = struct {
+	sync.Mutex
+	hooks []func()
+}{}
+
+// AtExit registers a function to be run by Exit, in reverse order of registration.
+func AtExit(hook func()) {
+	exitHooks.Lock()
+	exitHooks.hooks = append(exitHooks.hooks, hook)
+	exitHooks.Unlock()
+}
+
+// CloseOnExit registers the logger to be closed by Exit.
+func CloseOnExit(logger *Logger) {
+	AtExit(func() { _ = logger.Close() })
+}
+
+// RunExitHooks runs and removes all registered exit hooks, e.g. from a deferred call in main.
+func RunExitHooks() {
+	exitHooks.Lock()
+	hooks := exitHooks.hooks
+	exitHooks.hooks = nil
+	exitHooks.Unlock()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+}
+
+// Exit runs the exit hooks and terminates the process with code.
+func Exit(code int) {
+	RunExitHooks()
+	os.Exit(code)
+}
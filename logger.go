@@ -1,16 +1,15 @@
 package go_logger
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/jeschu/go-logger/colors"
 	"golang.org/x/term"
 	"io"
 	"os"
-	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -52,9 +51,56 @@ func (level Level) Long() string {
 		return "?"
 	}
 }
+func (level Level) String() string { return level.Long() }
 func (level Level) MarshalJSON() ([]byte, error) {
+	return []byte("\"" + level.Long() + "\""), nil
+}
+func (level Level) MarshalText() ([]byte, error) {
 	return []byte(level.Long()), nil
 }
+func (level *Level) UnmarshalText(text []byte) error {
+	parsed, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*level = parsed
+	return nil
+}
+func (level *Level) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '"' {
+		n, err := strconv.Atoi(string(data))
+		if err != nil || n < int(TRACE) || n > int(FATAL) {
+			return fmt.Errorf("go_logger: invalid level %s", data)
+		}
+		*level = Level(n)
+		return nil
+	}
+	text, err := strconv.Unquote(string(data))
+	if err != nil {
+		return fmt.Errorf("go_logger: invalid level %s", data)
+	}
+	return level.UnmarshalText([]byte(text))
+}
+
+// ParseLevel accepts the long and short level names case-insensitively, plus "warning".
+func ParseLevel(text string) (Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(text)) {
+	case "TRACE", "T":
+		return TRACE, nil
+	case "DEBUG", "D":
+		return DEBUG, nil
+	case "INFO", "I":
+		return INFO, nil
+	case "WARN", "WARNING", "W":
+		return WARN, nil
+	case "ERROR", "E":
+		return ERROR, nil
+	case "FATAL", "F":
+		return FATAL, nil
+	default:
+		return INFO, fmt.Errorf("go_logger: unknown level %q", text)
+	}
+}
 
 const (
 	TRACE Level = iota
@@ -65,53 +111,158 @@ const (
 	FATAL
 )
 
+// levelOff is above all levels and used to disable level dependent features.
+const levelOff = FATAL + 1
+
 type Format int
 
 const (
 	PLAIN Format = iota
 	JSON
+	// PRETTY is the multi-line development format of PrettyEncoder.
+	PRETTY
 )
 
+func (format Format) String() string {
+	switch format {
+	case PLAIN:
+		return "plain"
+	case JSON:
+		return "json"
+	case PRETTY:
+		return "pretty"
+	default:
+		return "?"
+	}
+}
+func (format Format) MarshalText() ([]byte, error) {
+	return []byte(format.String()), nil
+}
+func (format *Format) UnmarshalText(text []byte) error {
+	parsed, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*format = parsed
+	return nil
+}
+
+func ParseFormat(text string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "plain", "text":
+		return PLAIN, nil
+	case "json":
+		return JSON, nil
+	case "pretty", "console":
+		return PRETTY, nil
+	default:
+		return PLAIN, fmt.Errorf("go_logger: unknown format %q", text)
+	}
+}
+
+// Logger is configured with builder methods like Level, Format or Out. These return a configured copy
+// and leave the receiver unchanged, so loggers can be shared and derived concurrently. SetLevel is the
+// only method changing a logger in place.
 type Logger struct {
 	out                    io.Writer
+	errorOut               io.Writer
 	name                   string
-	level                  Level
+	level                  *atomic.Int32
 	format                 Format
 	colorizedSet           bool
+	icons                  bool
 	colors                 cls
 	panicOnFatal           bool
+	exitOnFatal            bool
+	exitCode               int
+	exitFunc               func(code int)
+	panicLevel             Level
+	repanic                bool
+	errorChain             bool
+	err                    error
+	omitGoroutine          bool
 	maxNameLength          int
 	maxGoroutineNameLength int
+	fields                 []Field
+	encoder                Encoder
+	sinks                  []Sink
+	caller                 bool
+	callerSkip             int
+	stackTraceLevel        Level
+	onWriteError           func(error, *Event)
+	rateLimiter            *rateLimiter
+	redactors              []Redactor
+	filters                []func(*Event) bool
+	preEncodeHooks         []func(*Event)
+	postWriteHooks         []func(*Event)
+	recorder               *FlightRecorder
+	escalation             *escalation
+	stats                  *loggerStats
+	clock                  Clock
+	timeLayout             string
+	timeLocation           *time.Location
+	timePrecision          Precision
+	timeOrigin             time.Time
+	maxMessageLength       int
+	multiline              Multiline
+	binaryMode             BinaryMode
+	followsConfig          bool
 }
 
 type Event struct {
 	Timestamp   time.Time
+	Logger      string
 	GoroutineId string
 	Level       Level
 	Message     string
 	Err         error
+	Fields      []Field
+	Caller      Caller
+	Stack       []Caller
+	// enriched is set once global and goroutine fields were added, so that Emit does not add them again.
+	enriched bool
+}
+
+// Clone copies the event including its fields. Events passed to sinks and hooks are reused afterwards,
+// so keep a clone to hold on to one, e.g. to emit it later.
+func (event *Event) Clone() *Event {
+	c := *event
+	if event.Fields != nil {
+		c.Fields = make([]Field, len(event.Fields))
+		copy(c.Fields, event.Fields)
+	}
+	return &c
 }
 
 //goland:noinspection GoUnusedExportedFunction
 func NewLogger(name string) *Logger {
 	return &Logger{
 		out:                    os.Stderr,
-		level:                  WARN,
+		level:                  newLevel(WARN),
 		name:                   name,
 		format:                 PLAIN,
 		colorizedSet:           false,
-		colors:                 clsOn,
+		colors:                 defaultColors(),
 		panicOnFatal:           false,
+		panicLevel:             ERROR,
 		maxNameLength:          10,
 		maxGoroutineNameLength: 10,
+		stackTraceLevel:        levelOff,
+		stats:                  statsFor(name),
 	}
 }
 
 func (logger *Logger) Out(out io.Writer) *Logger {
+	logger = logger.derive()
+	logger.followsConfig = false
 	logger.out = out
 	if !logger.colorizedSet {
 		if f, ok := out.(*os.File); ok {
-			if term.IsTerminal(int(f.Fd())) {
+			colorized, decided := colorsFromEnv()
+			if !decided {
+				colorized = term.IsTerminal(int(f.Fd()))
+			}
+			if colorized {
 				logger.colors = clsOn
 			} else {
 				logger.colors = clsOff
@@ -120,15 +271,93 @@ func (logger *Logger) Out(out io.Writer) *Logger {
 	}
 	return logger
 }
+
+// ErrorOut sends ERROR and FATAL lines to out instead of Out. Pass nil to write all lines to Out again.
+func (logger *Logger) ErrorOut(out io.Writer) *Logger {
+	logger = logger.derive()
+	logger.followsConfig = false
+	logger.errorOut = out
+	return logger
+}
+
+// SplitStd writes WARN and below to stdout and ERROR and FATAL to stderr, the convention container
+// platforms use to classify log streams.
+func (logger *Logger) SplitStd() *Logger {
+	return logger.Out(os.Stdout).ErrorOut(os.Stderr)
+}
+
+// colorsFromEnv applies the NO_COLOR, CLICOLOR_FORCE and CLICOLOR conventions in this order. decided is
+// false if none of them is set and colors depend on the terminal.
+func colorsFromEnv() (colorized bool, decided bool) {
+	if os.Getenv("NO_COLOR") != "" {
+		return false, true
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true, true
+	}
+	if os.Getenv("CLICOLOR") == "0" {
+		return false, true
+	}
+	return false, false
+}
+
+func defaultColors() cls {
+	if colorized, decided := colorsFromEnv(); decided && !colorized {
+		return clsOff
+	}
+	return clsOn
+}
 func (logger *Logger) Format(format Format) *Logger {
+	logger = logger.derive()
+	logger.followsConfig = false
 	logger.format = format
 	return logger
 }
+
+// Encoder replaces the built-in PLAIN and JSON output with a custom encoder. Pass nil to go back to Format.
+func (logger *Logger) Encoder(encoder Encoder) *Logger {
+	logger = logger.derive()
+	logger.followsConfig = false
+	logger.encoder = encoder
+	return logger
+}
 func (logger *Logger) Level(level Level) *Logger {
-	logger.level = level
+	logger = logger.derive()
+	logger.level = newLevel(level)
 	return logger
 }
+
+// Clone returns a copy of the logger with its own level.
+func (logger *Logger) Clone() *Logger {
+	child := logger.derive()
+	child.level = newLevel(logger.GetLevel())
+	return child
+}
+
+// derive returns a copy for builder methods, sharing level, stats and rate limiter with the logger.
+func (logger *Logger) derive() *Logger {
+	child := *logger
+	return &child
+}
+
+// SetLevel changes the level of a live logger and may be called concurrently to logging. Loggers derived
+// with With share the level with their parent, Named loggers get their own copy.
+func (logger *Logger) SetLevel(level Level) {
+	logger.level.Store(int32(level))
+}
+func (logger *Logger) GetLevel() Level {
+	return Level(logger.level.Load())
+}
+
+func newLevel(level Level) *atomic.Int32 {
+	l := &atomic.Int32{}
+	l.Store(int32(level))
+	return l
+}
+
+// Colorized turns colors on or off regardless of the terminal and of NO_COLOR, CLICOLOR and CLICOLOR_FORCE.
 func (logger *Logger) Colorized(colorized bool) *Logger {
+	logger = logger.derive()
 	logger.colorizedSet = true
 	if colorized {
 		logger.colors = clsOn
@@ -137,110 +366,171 @@ func (logger *Logger) Colorized(colorized bool) *Logger {
 	}
 	return logger
 }
+
+// IncludeGoroutine controls whether events carry the id or name of the logging goroutine, which is on
+// by default. Turning it off saves the lookup and omits the goroutine from the output.
+func (logger *Logger) IncludeGoroutine(include bool) *Logger {
+	logger = logger.derive()
+	logger.omitGoroutine = !include
+	return logger
+}
+
+// Icons prefixes lines of the PRETTY format with a marker for their level.
+func (logger *Logger) Icons(icons bool) *Logger {
+	logger = logger.derive()
+	logger.icons = icons
+	return logger
+}
 func (logger *Logger) PanicOnFatal(panicOnFatal bool) *Logger {
+	logger = logger.derive()
 	logger.panicOnFatal = panicOnFatal
 	return logger
 }
+
+// ExitOnFatal makes FATAL events flush the logger and terminate the process with code via Exit, so exit
+// hooks run. A negative code turns this off. Exiting happens before PanicOnFatal is considered.
+func (logger *Logger) ExitOnFatal(code int) *Logger {
+	logger = logger.derive()
+	logger.exitOnFatal = code >= 0
+	logger.exitCode = code
+	return logger
+}
+
+// ExitFunc replaces Exit for ExitOnFatal, e.g. to record the exit code in tests. nil restores Exit.
+func (logger *Logger) ExitFunc(exit func(code int)) *Logger {
+	logger = logger.derive()
+	logger.exitFunc = exit
+	return logger
+}
 func (logger *Logger) MaxNameLength(length int) *Logger {
+	logger = logger.derive()
 	logger.maxNameLength = length
 	return logger
 }
 func (logger *Logger) MaxGoroutineNameLength(length int) *Logger {
+	logger = logger.derive()
 	logger.maxGoroutineNameLength = length
 	return logger
 }
 
-func (logger *Logger) Trace(msg string) { logger.log(createEvent(TRACE, msg, nil)) }
-func (logger *Logger) Debug(msg string) { logger.log(createEvent(DEBUG, msg, nil)) }
-func (logger *Logger) Info(msg string)  { logger.log(createEvent(INFO, msg, nil)) }
-func (logger *Logger) Warn(msg string)  { logger.log(createEvent(WARN, msg, nil)) }
-func (logger *Logger) Error(msg string) { logger.log(createEvent(ERROR, msg, nil)) }
-func (logger *Logger) Fatal(msg string) { logger.log(createEvent(FATAL, msg, nil)) }
+func (logger *Logger) Trace(msg string) { logger.logMsg(TRACE, msg, nil) }
+func (logger *Logger) Debug(msg string) { logger.logMsg(DEBUG, msg, nil) }
+func (logger *Logger) Info(msg string)  { logger.logMsg(INFO, msg, nil) }
+func (logger *Logger) Warn(msg string)  { logger.logMsg(WARN, msg, nil) }
+func (logger *Logger) Error(msg string) { logger.logMsg(ERROR, msg, nil) }
+func (logger *Logger) Fatal(msg string) { logger.logMsg(FATAL, msg, nil) }
+
+// Log logs msg at a level only known at runtime, e.g. when forwarding from other logging APIs.
+func (logger *Logger) Log(level Level, msg string) { logger.logMsg(level, msg, nil) }
+func (logger *Logger) Logf(level Level, format string, args ...any) {
+	logger.logf(level, nil, format, args)
+}
 func (logger *Logger) Tracef(format string, args ...any) {
-	logger.log(createEvent(TRACE, fmt.Sprintf(format, args...), nil))
+	logger.logf(TRACE, nil, format, args)
 }
 func (logger *Logger) Debugf(format string, args ...any) {
-	logger.log(createEvent(DEBUG, fmt.Sprintf(format, args...), nil))
+	logger.logf(DEBUG, nil, format, args)
 }
 func (logger *Logger) Infof(format string, args ...any) {
-	logger.log(createEvent(INFO, fmt.Sprintf(format, args...), nil))
+	logger.logf(INFO, nil, format, args)
 }
 func (logger *Logger) Warnf(format string, args ...any) {
-	logger.log(createEvent(WARN, fmt.Sprintf(format, args...), nil))
+	logger.logf(WARN, nil, format, args)
 }
 func (logger *Logger) Errorf(format string, args ...any) {
-	logger.log(createEvent(ERROR, fmt.Sprintf(format, args...), nil))
+	logger.logf(ERROR, nil, format, args)
 }
 func (logger *Logger) Fatalf(format string, args ...any) {
-	logger.log(createEvent(FATAL, fmt.Sprintf(format, args...), nil))
+	logger.logf(FATAL, nil, format, args)
 }
 
 func (logger *Logger) TraceErr(err error, msg string) {
 	if err != nil {
-		logger.log(createEvent(TRACE, msg, err))
+		logger.logMsg(TRACE, msg, err)
 	}
 }
 func (logger *Logger) DebugErr(err error, msg string) {
 	if err != nil {
-		logger.log(createEvent(DEBUG, msg, err))
+		logger.logMsg(DEBUG, msg, err)
 	}
 }
 func (logger *Logger) InfoErr(err error, msg string) {
 	if err != nil {
-		logger.log(createEvent(INFO, msg, err))
+		logger.logMsg(INFO, msg, err)
 	}
 }
 func (logger *Logger) WarnErr(err error, msg string) {
 	if err != nil {
-		logger.log(createEvent(WARN, msg, err))
+		logger.logMsg(WARN, msg, err)
 	}
 }
 func (logger *Logger) ErrorErr(err error, msg string) {
 	if err != nil {
-		logger.log(createEvent(ERROR, msg, err))
+		logger.logMsg(ERROR, msg, err)
 	}
 }
 func (logger *Logger) FatalErr(err error, msg string) {
 	if err != nil {
-		logger.log(createEvent(FATAL, msg, err))
+		logger.logMsg(FATAL, msg, err)
 	}
 }
 func (logger *Logger) TraceErrf(err error, format string, args ...any) {
 	if err != nil {
-		logger.log(createEvent(TRACE, fmt.Sprintf(format, args...), err))
+		logger.logf(TRACE, err, format, args)
 	}
 }
 func (logger *Logger) DebugErrf(err error, format string, args ...any) {
 	if err != nil {
-		logger.log(createEvent(DEBUG, fmt.Sprintf(format, args...), err))
+		logger.logf(DEBUG, err, format, args)
 	}
 }
 func (logger *Logger) InfoErrf(err error, format string, args ...any) {
 	if err != nil {
-		logger.log(createEvent(INFO, fmt.Sprintf(format, args...), err))
+		logger.logf(INFO, err, format, args)
 	}
 }
 func (logger *Logger) WarnErrf(err error, format string, args ...any) {
 	if err != nil {
-		logger.log(createEvent(WARN, fmt.Sprintf(format, args...), err))
+		logger.logf(WARN, err, format, args)
 	}
 }
 func (logger *Logger) ErrorErrf(err error, format string, args ...any) {
 	if err != nil {
-		logger.log(createEvent(ERROR, fmt.Sprintf(format, args...), err))
+		logger.logf(ERROR, err, format, args)
 	}
 }
 func (logger *Logger) FatalErrf(err error, format string, args ...any) {
 	if err != nil {
-		logger.log(createEvent(FATAL, fmt.Sprintf(format, args...), err))
+		logger.logf(FATAL, err, format, args)
 	}
 }
-func (logger *Logger) IsTrace() bool { return logger.level <= TRACE }
-func (logger *Logger) IsDebug() bool { return logger.level <= DEBUG }
-func (logger *Logger) IsInfo() bool  { return logger.level <= INFO }
-func (logger *Logger) IsWarn() bool  { return logger.level <= WARN }
-func (logger *Logger) IsError() bool { return logger.level <= ERROR }
-func (logger *Logger) IsFatal() bool { return logger.level <= FATAL }
+
+// enabled reports whether an event at level would be logged, FATAL events being always handled when
+// they panic or exit.
+func (logger *Logger) enabled(level Level) bool {
+	return level >= logger.GetLevel() || level == FATAL && (logger.panicOnFatal || logger.exitOnFatal) ||
+		logger.recorder != nil && level >= logger.recorder.level ||
+		logger.escalation != nil && logger.escalation.enabled(level)
+}
+
+// logMsg and logf check the level before creating the event, so that disabled calls do not allocate.
+func (logger *Logger) logMsg(level Level, msg string, err error) {
+	if logger.enabled(level) {
+		logger.log(createEvent(level, msg, err))
+	}
+}
+func (logger *Logger) logf(level Level, err error, format string, args []any) {
+	if logger.enabled(level) {
+		logger.log(createEvent(level, fmt.Sprintf(format, args...), err))
+	}
+}
+
+func (logger *Logger) IsTrace() bool { return logger.GetLevel() <= TRACE }
+func (logger *Logger) IsDebug() bool { return logger.GetLevel() <= DEBUG }
+func (logger *Logger) IsInfo() bool  { return logger.GetLevel() <= INFO }
+func (logger *Logger) IsWarn() bool  { return logger.GetLevel() <= WARN }
+func (logger *Logger) IsError() bool { return logger.GetLevel() <= ERROR }
+func (logger *Logger) IsFatal() bool { return logger.GetLevel() <= FATAL }
 
 //goland:noinspection GoUnusedExportedFunction
 func SetGoroutineName(name string) func() {
@@ -272,103 +562,327 @@ func goroutineName(id int) string {
 	}
 }
 
+// addGoroutine sets the goroutine name and appends the fields pushed by the goroutine.
+func (logger *Logger) addGoroutine(event *Event) {
+	needsName := !logger.omitGoroutine && event.GoroutineId == ""
+	needsFields := mdcSize.Load() > 0
+	if !needsName && !needsFields {
+		return
+	}
+	id := goroutineId()
+	if needsName {
+		event.GoroutineId = goroutineName(id)
+	}
+	if needsFields {
+		if fields := goroutineFields(id); len(fields) > 0 {
+			event.Fields = append(event.Fields[:len(event.Fields):len(event.Fields)], fields...)
+		}
+	}
+}
+
+// Emit logs a copy of event through this logger, e.g. to forward events between loggers or to replay
+// events kept with Clone. Level, filters, hooks, redaction and sinks of this logger apply and its
+// context fields are added, while timestamp, logger name, goroutine, caller and fields of event are
+// kept if set. Global and goroutine fields are only added to events that were not logged before.
+// event itself is not changed.
+func (logger *Logger) Emit(event *Event) {
+	if !logger.enabled(event.Level) {
+		return
+	}
+	emitted := eventPool.Get().(*Event)
+	*emitted = *event
+	emitted.Fields = append(logger.fields[:len(logger.fields):len(logger.fields)], event.Fields...)
+	if emitted.Timestamp.IsZero() {
+		emitted.Timestamp = logger.now()
+	}
+	if emitted.Logger == "" {
+		emitted.Logger = logger.name
+	}
+	logger.emit(emitted)
+}
+
 func (logger *Logger) log(event *Event) {
-	if event.Level >= logger.level {
-		switch logger.format {
-		case PLAIN:
-			logger.logPlain(event)
-		case JSON:
-			logger.logJson(event)
+	if logger.clock != nil {
+		event.Timestamp = logger.clock.Now()
+	}
+	event.Logger = logger.name
+	if event.Fields == nil {
+		event.Fields = logger.fields
+	}
+	logger.emit(event)
+}
+
+// emit runs a complete event through the pipeline and releases it.
+func (logger *Logger) emit(event *Event) {
+	if event.Err == nil {
+		event.Err = logger.err
+	}
+	active := event.Level >= logger.GetLevel() ||
+		logger.escalation != nil && logger.escalation.admit(event.Level, event.Timestamp)
+	if (active || logger.recorder != nil) && !event.enriched {
+		event.Fields = addGlobalFields(event.Fields)
+		logger.addGoroutine(event)
+		event.enriched = true
+	}
+	sanitized := false
+	if active && logger.accepts(event) && !logger.rateLimited(event) {
+		if logger.caller && !event.Caller.Defined() {
+			event.Caller = captureCaller(logger.callerSkip)
+		}
+		if event.Level >= logger.stackTraceLevel && event.Stack == nil {
+			event.Stack = captureStack()
+		}
+		logger.runPreEncodeHooks(event)
+		logger.sanitize(event)
+		sanitized = true
+		if logger.write(event) {
+			logger.runPostWriteHooks(event)
+		}
+		if logger.escalation != nil && event.Level >= logger.escalation.trigger {
+			logger.escalation.start(event.Timestamp)
+		}
+	}
+	if logger.recorder != nil {
+		if !sanitized {
+			logger.sanitize(event)
+		}
+		logger.recorder.record(event)
+	}
+	if event.Level == FATAL && logger.exitOnFatal {
+		_ = logger.Flush()
+		if logger.exitFunc != nil {
+			logger.exitFunc(logger.exitCode)
+		} else {
+			Exit(logger.exitCode)
 		}
 	}
 	if event.Level == FATAL && logger.panicOnFatal {
+		_ = logger.Flush()
 		panic(event.Err)
 	}
+	releaseEvent(event)
+}
+
+// sanitize redacts, makes binary data safe, escapes and truncates event before it is written or recorded.
+func (logger *Logger) sanitize(event *Event) {
+	if len(logger.redactors) > 0 {
+		redactEvent(event, logger.redactors)
+	}
+	if logger.binaryMode != BinaryRaw {
+		binarySafeEvent(event, logger.binaryMode)
+	}
+	if logger.multiline == MultilineEscape {
+		event.Message = strings.ReplaceAll(event.Message, "\n", "\\n")
+	}
+	if logger.maxMessageLength > 0 {
+		truncateEvent(event, logger.maxMessageLength)
+	}
 }
 
-func (logger *Logger) logPlain(event *Event) {
-	sb := strings.Builder{}
-	sb.WriteString(logger.colors.Timestamp.String())
-	sb.WriteString(event.Timestamp.Format(time.RFC3339))
-	sb.WriteString(levelColored(logger, event.Level))
+// write writes event to the sinks or the output and reports whether all writes succeeded.
+func (logger *Logger) write(event *Event) bool {
+	start := metricsStart()
+	ok := true
+	sinks, format := logger.sinks, logger.format
+	if output := logger.configuredOutput(); output != nil {
+		defer output.release()
+		sinks, format = output.sinks, output.format
+	}
+	if len(sinks) > 0 {
+		for _, sink := range sinks {
+			if err := sink.Write(event); err != nil {
+				logger.writeError(err, event)
+				ok = false
+			}
+		}
+	} else if logger.encoder != nil {
+		ok = logger.logEncoded(logger.encoder, event)
+	} else {
+		switch format {
+		case PLAIN:
+			encoder := PlainEncoder{
+				colors:                 logger.colors,
+				MaxNameLength:          logger.maxNameLength,
+				MaxGoroutineNameLength: logger.maxGoroutineNameLength,
+				TimeLayout:             logger.timestampLayout(),
+				TimeLocation:           logger.timeLocation,
+				TimeOrigin:             logger.timeOrigin,
+				ErrorChain:             logger.errorChain,
+				Multiline:              logger.multiline,
+			}
+			ok = logger.logEncoded(&encoder, event)
+		case PRETTY:
+			encoder := PrettyEncoder{
+				colors:       logger.colors,
+				Icons:        logger.icons,
+				ErrorChain:   logger.errorChain,
+				NameWidth:    logger.maxNameLength,
+				TimeLayout:   logger.timestampLayout(),
+				TimeLocation: logger.timeLocation,
+				TimeOrigin:   logger.timeOrigin,
+			}
+			if encoder.TimeLayout == "" {
+				encoder.TimeLayout = "15:04:05.000"
+			}
+			ok = logger.logEncoded(&encoder, event)
+		case JSON:
+			layout := logger.timestampLayout()
+			if layout == "" && logger.timeLocation == nil {
+				ok = logger.logEncoded(defaultJSONEncoder, event)
+			} else {
+				encoder := JSONEncoder{
+					Keys:         DefaultJSONKeys,
+					TimeLayout:   layout,
+					TimeLocation: logger.timeLocation,
+					TimeOrigin:   logger.timeOrigin,
+				}
+				ok = logger.logEncoded(&encoder, event)
+			}
+		}
+	}
+	observeLatency(&metrics.write, start)
+	countEvent(event)
+	logger.stats.emitted.Add(1)
+	return ok
+}
+
+// PlainEncoder writes the human-readable single line format. Name and goroutine are padded or
+// truncated to the configured lengths unless these are zero. Timestamps are formatted as described at
+// Logger.TimestampFormat.
+type PlainEncoder struct {
+	colors                 cls
+	MaxNameLength          int
+	MaxGoroutineNameLength int
+	TimeLayout             string
+	TimeLocation           *time.Location
+	TimeOrigin             time.Time
+	// ErrorChain writes every cause of the error on a line of its own.
+	ErrorChain bool
+	Multiline  Multiline
+}
+
+func NewPlainEncoder(colorized bool) *PlainEncoder {
+	encoder := &PlainEncoder{colors: clsOff, MaxNameLength: 10, MaxGoroutineNameLength: 10}
+	if colorized {
+		encoder.colors = clsOn
+	}
+	return encoder
+}
+
+func (encoder *PlainEncoder) Encode(sb *strings.Builder, event *Event) {
+	encodeTo(sb, encoder, event)
+}
+
+func (encoder *PlainEncoder) encode(sb *buffer, event *Event) {
+	start := sb.Len()
+	sb.WriteString(encoder.colors.Timestamp.String())
+	var buf [64]byte
+	sb.Write(appendTimestamp(buf[:0], event.Timestamp, encoder.TimeLayout, encoder.TimeLocation, encoder.TimeOrigin))
+	sb.WriteString(levelColored(encoder.colors, event.Level))
 	sb.WriteString(" -")
 	sb.WriteString(event.Level.Short())
 	sb.WriteString("-")
-	sb.WriteString(logger.colors.Logger.String())
+	sb.WriteString(encoder.colors.Logger.String())
 	sb.WriteString(" [")
-	name := logger.name
-	maxNameLength := logger.maxNameLength
-	if maxNameLength > 0 {
-		name = stringToLength(name, maxNameLength)
-	}
-	sb.WriteString(name)
+	writeToLength(sb, event.Logger, encoder.MaxNameLength)
 	sb.WriteString("] ")
-	sb.WriteString(logger.colors.GoRoutine.String())
-	sb.WriteString("(")
-	goId := event.GoroutineId
-	maxGoroutineNameLength := logger.maxGoroutineNameLength
-	if maxGoroutineNameLength > 0 {
-		goId = stringToLength(goId, maxNameLength)
-	}
-	sb.WriteString(goId)
-	sb.WriteString(") ")
-	sb.WriteString(messageColored(logger, event.Level))
-	sb.WriteString(event.Message)
+	if event.GoroutineId != "" {
+		sb.WriteString(encoder.colors.GoRoutine.String())
+		sb.WriteString("(")
+		writeToLength(sb, event.GoroutineId, encoder.MaxGoroutineNameLength)
+		sb.WriteString(") ")
+	}
+	if event.Caller.Defined() {
+		sb.WriteString(event.Caller.String())
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(messageColored(encoder.colors, event.Level))
+	encoder.writeMessage(sb, event.Message, start)
 	if event.Err != nil {
 		sb.WriteString(": ")
 		sb.WriteString(event.Err.Error())
 	}
-	sb.WriteString(colors.END.String())
+	for _, field := range event.Fields {
+		sb.WriteByte(' ')
+		sb.WriteString(field.Key)
+		sb.WriteByte('=')
+		field.writeText(sb)
+	}
+	if encoder.ErrorChain && event.Err != nil {
+		writeErrorChain(sb, event.Err)
+	}
+	if event.Err != nil {
+		writeErrorStack(sb, event.Err)
+	}
+	writeStackFrames(sb, event.Stack, "\t")
+	sb.WriteString(encoder.colors.End.String())
 	sb.WriteByte('\n')
-	_, _ = fmt.Fprintf(logger.out, sb.String())
 }
 
-func levelColored(logger *Logger, level Level) string {
+func levelColored(palette cls, level Level) string {
 	switch level {
 	case TRACE:
-		return logger.colors.Trace.String()
+		return palette.Trace.String()
 	case DEBUG:
-		return logger.colors.Debug.String()
+		return palette.Debug.String()
 	case INFO:
-		return logger.colors.Info.String()
+		return palette.Info.String()
 	case WARN:
-		return logger.colors.Warn.String()
+		return palette.Warn.String()
 	case ERROR:
-		return logger.colors.Error.String()
+		return palette.Error.String()
 	case FATAL:
-		return logger.colors.Fatal.String()
+		return palette.Fatal.String()
 	default:
-		return logger.colors.Default.String()
+		return palette.Default.String()
 	}
 }
-func messageColored(logger *Logger, level Level) string {
+func messageColored(palette cls, level Level) string {
 	switch level {
 	case TRACE:
-		return logger.colors.Message.String()
+		return palette.Message.String()
 	case DEBUG:
-		return logger.colors.Message.String()
+		return palette.Message.String()
 	case INFO:
-		return logger.colors.Message.String()
+		return palette.Message.String()
 	case WARN:
-		if logger.colors.MessageLevel {
-			return logger.colors.Warn.String()
+		if palette.MessageLevel {
+			return palette.Warn.String()
 		} else {
-			return logger.colors.Message.String()
+			return palette.Message.String()
 		}
 	case ERROR:
-		if logger.colors.MessageLevel {
-			return logger.colors.Error.String()
+		if palette.MessageLevel {
+			return palette.Error.String()
 		} else {
-			return logger.colors.Message.String()
+			return palette.Message.String()
 		}
 	case FATAL:
-		if logger.colors.MessageLevel {
-			return logger.colors.Fatal.String()
+		if palette.MessageLevel {
+			return palette.Fatal.String()
 		} else {
-			return logger.colors.Message.String()
+			return palette.Message.String()
 		}
 	default:
-		return logger.colors.Default.String()
+		return palette.Default.String()
+	}
+}
+
+// writeToLength writes str like stringToLength without allocating. A length of zero writes str unchanged.
+func writeToLength(sb *buffer, str string, length int) {
+	switch {
+	case length <= 0 || len(str) == length:
+		sb.WriteString(str)
+	case len(str) > length && length <= 3:
+		sb.WriteString(str[:length])
+	case len(str) > length:
+		sb.WriteString(str[:length-3])
+		sb.WriteString("...")
+	default:
+		sb.WriteString(str)
+		for i := len(str); i < length; i++ {
+			sb.WriteByte(' ')
+		}
 	}
 }
 
@@ -382,54 +896,44 @@ func stringToLength(str string, length int) string {
 	return s
 }
 
-func (logger *Logger) logJson(event *Event) {
-	sb := strings.Builder{}
-	sb.WriteString("{\"timestamo\":\"")
-	sb.WriteString(event.Timestamp.Format(time.RFC3339))
-	sb.WriteString("\",\"logger\":\"")
-	sb.WriteString(logger.name)
-	sb.WriteString("\",\"level\":\"")
-	sb.WriteString(event.Level.Short())
-	sb.WriteString("\",\"goroutineId\":\"")
-	sb.WriteString(event.GoroutineId)
-	sb.WriteString("\",\"message\":\"")
-	message, _ := json.Marshal(event.Message)
-	sb.Write(message)
-	sb.WriteString("\"")
-	if event.Err != nil {
-		sb.WriteString(":\"error\":\"")
-		err, _ := json.Marshal(event.Err.Error())
-		sb.Write(err)
-		sb.WriteString("\"")
+func (logger *Logger) logEncoded(encoder Encoder, event *Event) bool {
+	start := metricsStart()
+	buf := encodeEvent(encoder, event)
+	defer putBuffer(buf)
+	observeLatency(&metrics.encode, start)
+	out := logger.out
+	if logger.errorOut != nil && event.Level >= ERROR {
+		out = logger.errorOut
+	}
+	if err := writeFullBytes(out, *buf); err != nil {
+		logger.writeError(err, event)
+		return false
 	}
-	sb.WriteString("}\n")
-	_, _ = fmt.Fprintf(logger.out, sb.String())
+	return true
 }
 
 func createEvent(level Level, msg string, err error) *Event {
 	timestamp := time.Now()
-	if msg[len(msg)-1] == '\n' {
+	if len(msg) > 0 && msg[len(msg)-1] == '\n' {
 		msg = msg[:len(msg)-1]
 	}
-	msg = strings.ReplaceAll(msg, "\n", "\\n")
-	return &Event{
-		Timestamp:   timestamp,
-		GoroutineId: goroutineName(goroutineId()),
-		Level:       level,
-		Message:     msg,
-		Err:         err,
-	}
+	event := eventPool.Get().(*Event)
+	event.Timestamp = timestamp
+	event.Level = level
+	event.Message = msg
+	event.Err = err
+	return event
 }
 
-func goroutineId() int {
-	var buf [64]byte
-	n := runtime.Stack(buf[:], false)
-	idField := strings.Fields(strings.TrimPrefix(string(buf[:n]), "goroutine "))[0]
-	id, err := strconv.Atoi(idField)
-	if err != nil {
-		return -1
-	}
-	return id
+// Events are pooled and reused once logged. Sinks and hooks must not keep an event after returning
+// but clone it, like AsyncSink does.
+var eventPool = sync.Pool{
+	New: func() any { return new(Event) },
+}
+
+func releaseEvent(event *Event) {
+	*event = Event{}
+	eventPool.Put(event)
 }
 
 type cls struct {
@@ -444,6 +948,7 @@ type cls struct {
 	Logger       colors.Color
 	GoRoutine    colors.Color
 	Message      colors.Color
+	End          colors.Color
 	MessageLevel bool
 }
 
@@ -459,10 +964,11 @@ var clsOn = cls{
 	Logger:       colors.VIOLET,
 	GoRoutine:    colors.VIOLET2,
 	Message:      colors.WHITE,
+	End:          colors.END,
 	MessageLevel: true,
 }
 
 var clsOff = cls{
 	Default: "", Timestamp: "", Trace: "", Debug: "", Info: "", Warn: "", Error: "", Fatal: "", Logger: "",
-	GoRoutine: "", Message: "", MessageLevel: false,
+	GoRoutine: "", Message: "", End: "", MessageLevel: false,
 }
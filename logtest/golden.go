@@ -0,0 +1,104 @@
+package logtest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// UpdateGoldenEnv names the environment variable which, set to a non-empty value, makes AssertGolden
+// write the golden files instead of comparing against them.
+const UpdateGoldenEnv = "LOGTEST_UPDATE_GOLDEN"
+
+// GoldenTime is the timestamp of all golden events.
+var GoldenTime = time.Date(2024, time.January, 2, 3, 4, 5, 123456789, time.UTC)
+
+// GoldenEvents returns a fixed set of events covering all levels, field types, errors, callers,
+// stack traces and characters needing escapes. The set only grows between releases, so golden files
+// fail on changed output, not on changed input.
+func GoldenEvents() []*golog.Event {
+	caller := golog.Caller{File: "/src/app/server/handler.go", Line: 42, Function: "app/server.(*Handler).ServeHTTP"}
+	return []*golog.Event{
+		{Timestamp: GoldenTime, Logger: "app", GoroutineId: "1", Level: golog.TRACE, Message: "trace message"},
+		{Timestamp: GoldenTime, Logger: "app", GoroutineId: "1", Level: golog.DEBUG, Message: "debug message"},
+		{Timestamp: GoldenTime, Logger: "app.http", GoroutineId: "17", Level: golog.INFO, Message: "request served",
+			Fields: []golog.Field{
+				golog.String("method", "GET"),
+				golog.Int("status", 200),
+				golog.Uint64("size", 1234),
+				golog.Float64("ratio", 0.25),
+				golog.Bool("cached", true),
+				golog.Duration("duration", 1500*time.Microsecond),
+				golog.Time("at", GoldenTime),
+			}},
+		{Timestamp: GoldenTime, Logger: "app.db", GoroutineId: "23", Level: golog.WARN, Message: "slow query",
+			Caller: caller,
+			Fields: []golog.Field{golog.Object("query", golog.String("table", "users"), golog.Int("rows", 3))}},
+		{Timestamp: GoldenTime, Logger: "app", GoroutineId: "1", Level: golog.ERROR, Message: "request failed",
+			Err:    errors.New("connection refused"),
+			Fields: []golog.Field{golog.Err(errors.New("dial tcp: timeout")), golog.Any("tags", []string{"a", "b"})}},
+		{Timestamp: GoldenTime, Logger: "a.very.long.logger.name", GoroutineId: "worker-with-a-long-name", Level: golog.FATAL,
+			Message: "escapes: \"quoted\" \\ tab\t unicode ü ☃",
+			Caller:  caller,
+			Stack:   []golog.Caller{caller, {File: "/usr/local/go/src/net/http/server.go", Line: 2136, Function: "net/http.HandlerFunc.ServeHTTP"}}},
+		{Timestamp: GoldenTime, Level: golog.INFO, Message: ""},
+	}
+}
+
+// RenderEvents encodes events with encoder and returns the concatenated output.
+func RenderEvents(encoder golog.Encoder, events []*golog.Event) string {
+	sb := strings.Builder{}
+	for _, event := range events {
+		encoder.Encode(&sb, event)
+	}
+	return sb.String()
+}
+
+// AssertGolden renders GoldenEvents with encoder and compares the output with the file at path,
+// reporting the first differing line. With the environment variable LOGTEST_UPDATE_GOLDEN set, the
+// file is written instead.
+func AssertGolden(tb testing.TB, encoder golog.Encoder, path string) {
+	tb.Helper()
+	AssertGoldenEvents(tb, encoder, GoldenEvents(), path)
+}
+
+// AssertGoldenEvents is AssertGolden for a custom set of events.
+func AssertGoldenEvents(tb testing.TB, encoder golog.Encoder, events []*golog.Event, path string) {
+	tb.Helper()
+	actual := RenderEvents(encoder, events)
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			tb.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+			tb.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		tb.Fatalf("reading golden file: %v (set %s=1 to create it)", err, UpdateGoldenEnv)
+	}
+	if actual == string(expected) {
+		return
+	}
+	actualLines := strings.Split(actual, "\n")
+	expectedLines := strings.Split(string(expected), "\n")
+	for i := 0; i < len(actualLines) || i < len(expectedLines); i++ {
+		var a, e string
+		if i < len(actualLines) {
+			a = actualLines[i]
+		}
+		if i < len(expectedLines) {
+			e = expectedLines[i]
+		}
+		if a != e {
+			tb.Fatalf("%s differs at line %d:\n  expected: %q\n  actual:   %q", path, i+1, e, a)
+		}
+	}
+}
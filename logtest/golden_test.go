@@ -0,0 +1,56 @@
+package logtest
+
+import (
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestGoldenPlain(t *testing.T) {
+	AssertGolden(t, golog.NewPlainEncoder(false), "testdata/plain.golden")
+}
+
+func TestGoldenPlainColorized(t *testing.T) {
+	AssertGolden(t, golog.NewPlainEncoder(true), "testdata/plain_colorized.golden")
+}
+
+func TestGoldenJSON(t *testing.T) {
+	AssertGolden(t, golog.NewJSONEncoder(), "testdata/json.golden")
+}
+
+func TestGoldenJSONCustomKeys(t *testing.T) {
+	encoder := golog.NewJSONEncoder()
+	encoder.Keys.Timestamp = "@timestamp"
+	encoder.Keys.Goroutine = ""
+	encoder.Keys.Stack = ""
+	AssertGolden(t, encoder, "testdata/json_custom_keys.golden")
+}
+
+func TestGoldenPretty(t *testing.T) {
+	AssertGolden(t, golog.NewPrettyEncoder(false), "testdata/pretty.golden")
+}
+
+func TestGoldenPrettyColorizedIcons(t *testing.T) {
+	encoder := golog.NewPrettyEncoder(true)
+	encoder.Icons = true
+	AssertGolden(t, encoder, "testdata/pretty_colorized_icons.golden")
+}
+
+func TestObserver(t *testing.T) {
+	logger, observer := NewObservedLogger(golog.INFO)
+	logger.Debug("dropped")
+	logger.With(golog.Int("n", 1)).Info("first")
+	logger.Warn("second")
+	if observer.Len() != 2 {
+		t.Fatalf("expected 2 events, got %d", observer.Len())
+	}
+	if n := observer.FilterField(golog.Int("n", 1)).Len(); n != 1 {
+		t.Errorf("expected 1 event with field n=1, got %d", n)
+	}
+	if n := observer.FilterLevel(golog.WARN).FilterMessage("second").Len(); n != 1 {
+		t.Errorf("expected 1 WARN event, got %d", n)
+	}
+	if events := observer.TakeAll(); len(events) != 2 || observer.Len() != 0 {
+		t.Errorf("TakeAll returned %d events, %d left", len(events), observer.Len())
+	}
+}
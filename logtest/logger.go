@@ -0,0 +1,68 @@
+// Package logtest provides loggers and sinks for tests.
+package logtest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// TestSink writes events via t.Log, so that output is shown with the test it belongs to and only
+// for failed tests or with -v. Events arriving after the test finished are dropped.
+type TestSink struct {
+	mutex   sync.Mutex
+	tb      testing.TB
+	encoder golog.Encoder
+	level   golog.Level
+	closed  bool
+}
+
+func NewTestSink(tb testing.TB) *TestSink {
+	encoder := golog.NewPlainEncoder(false)
+	sink := &TestSink{tb: tb, encoder: encoder, level: golog.TRACE}
+	tb.Cleanup(func() { _ = sink.Close() })
+	return sink
+}
+
+func (sink *TestSink) Encoder(encoder golog.Encoder) *TestSink {
+	sink.encoder = encoder
+	return sink
+}
+
+func (sink *TestSink) Level(level golog.Level) *TestSink {
+	sink.level = level
+	return sink
+}
+
+func (sink *TestSink) Write(event *golog.Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	sb := strings.Builder{}
+	sink.encoder.Encode(&sb, event)
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.closed {
+		return golog.ErrSinkClosed
+	}
+	sink.tb.Helper()
+	sink.tb.Log(strings.TrimSuffix(sb.String(), "\n"))
+	return nil
+}
+
+func (sink *TestSink) Flush() error { return nil }
+
+func (sink *TestSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.closed = true
+	return nil
+}
+
+// NewTestLogger returns a logger at level TRACE writing to a TestSink of tb. The sink is closed when
+// the test and its subtests have finished.
+func NewTestLogger(tb testing.TB) *golog.Logger {
+	return golog.NewLogger(tb.Name()).Level(golog.TRACE).Sinks(NewTestSink(tb))
+}
@@ -0,0 +1,125 @@
+package logtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// Observer is a sink recording copies of all events in memory, for assertions in tests. The filter
+// methods return a new Observer holding the matching events only.
+type Observer struct {
+	mutex  sync.Mutex
+	events []*golog.Event
+	level  golog.Level
+}
+
+func NewObserver() *Observer {
+	return &Observer{level: golog.TRACE}
+}
+
+// NewObservedLogger returns a logger at level whose events are recorded by the returned Observer.
+func NewObservedLogger(level golog.Level) (*golog.Logger, *Observer) {
+	observer := NewObserver()
+	return golog.NewLogger("").Level(level).Sinks(observer), observer
+}
+
+func (observer *Observer) Level(level golog.Level) *Observer {
+	observer.level = level
+	return observer
+}
+
+func (observer *Observer) Write(event *golog.Event) error {
+	if event.Level < observer.level {
+		return nil
+	}
+	observed := *event
+	observed.Fields = append([]golog.Field(nil), event.Fields...)
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	observer.events = append(observer.events, &observed)
+	return nil
+}
+
+func (observer *Observer) Flush() error { return nil }
+func (observer *Observer) Close() error { return nil }
+
+func (observer *Observer) Len() int {
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	return len(observer.events)
+}
+
+// All returns the recorded events in order.
+func (observer *Observer) All() []*golog.Event {
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	return append([]*golog.Event(nil), observer.events...)
+}
+
+// TakeAll returns the recorded events and removes them from the observer.
+func (observer *Observer) TakeAll() []*golog.Event {
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	events := observer.events
+	observer.events = nil
+	return events
+}
+
+func (observer *Observer) Filter(keep func(*golog.Event) bool) *Observer {
+	filtered := &Observer{level: observer.level}
+	for _, event := range observer.All() {
+		if keep(event) {
+			filtered.events = append(filtered.events, event)
+		}
+	}
+	return filtered
+}
+
+func (observer *Observer) FilterLevel(level golog.Level) *Observer {
+	return observer.Filter(func(event *golog.Event) bool { return event.Level == level })
+}
+
+func (observer *Observer) FilterLogger(name string) *Observer {
+	return observer.Filter(func(event *golog.Event) bool { return event.Logger == name })
+}
+
+func (observer *Observer) FilterMessage(msg string) *Observer {
+	return observer.Filter(func(event *golog.Event) bool { return event.Message == msg })
+}
+
+func (observer *Observer) FilterMessageSnippet(snippet string) *Observer {
+	return observer.Filter(func(event *golog.Event) bool { return strings.Contains(event.Message, snippet) })
+}
+
+// FilterField keeps events having a field with the key, type and value of field.
+func (observer *Observer) FilterField(field golog.Field) *Observer {
+	return observer.Filter(func(event *golog.Event) bool {
+		for _, f := range event.Fields {
+			if fieldsEqual(f, field) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (observer *Observer) FilterFieldKey(key string) *Observer {
+	return observer.Filter(func(event *golog.Event) bool {
+		for _, f := range event.Fields {
+			if f.Key == key {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func fieldsEqual(a, b golog.Field) bool {
+	if a.Key != b.Key || a.Type != b.Type || a.Integer != b.Integer || a.String != b.String {
+		return false
+	}
+	return a.Interface == nil && b.Interface == nil || fmt.Sprint(a.Interface) == fmt.Sprint(b.Interface)
+}
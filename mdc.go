@@ -0,0 +1,54 @@
+package go_logger
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// The mapped diagnostic context holds fields per goroutine id. mdcSize counts goroutines with fields,
+// so that events need no goroutine lookup while the context is unused.
+var (
+	mdcMutex  sync.RWMutex
+	mdcFields = make(map[int][]Field)
+	mdcSize   atomic.Int64
+)
+
+// PushGoroutineFields adds fields to every event logged from the calling goroutine until the returned
+// function is called, which restores the fields from before. Pushes nest and must be popped by the same
+// goroutine in reverse order, typically deferred:
+//
+//	defer go_logger.PushGoroutineFields(go_logger.String(go_logger.RequestIDKey, id))()
+func PushGoroutineFields(fields ...Field) func() {
+	id := goroutineId()
+	mdcMutex.Lock()
+	previous, ok := mdcFields[id]
+	mdcFields[id] = append(previous[:len(previous):len(previous)], fields...)
+	if !ok {
+		mdcSize.Add(1)
+	}
+	mdcMutex.Unlock()
+	return func() {
+		mdcMutex.Lock()
+		if ok {
+			mdcFields[id] = previous
+		} else {
+			delete(mdcFields, id)
+			mdcSize.Add(-1)
+		}
+		mdcMutex.Unlock()
+	}
+}
+
+// GoroutineFields returns the fields pushed by the calling goroutine.
+func GoroutineFields() []Field {
+	if mdcSize.Load() == 0 {
+		return nil
+	}
+	return goroutineFields(goroutineId())
+}
+
+func goroutineFields(id int) []Field {
+	mdcMutex.RLock()
+	defer mdcMutex.RUnlock()
+	return mdcFields[id]
+}
@@ -0,0 +1,64 @@
+package go_logger
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+var processFields = sync.OnceValue(func() []Field {
+	host, _ := os.Hostname()
+	executable, err := os.Executable()
+	if err != nil {
+		executable = os.Args[0]
+	}
+	return []Field{
+		String("host", host),
+		Int("pid", os.Getpid()),
+		String("executable", filepath.Base(executable)),
+		String("goVersion", runtime.Version()),
+	}
+})
+
+// ProcessFields returns the fields host, pid, executable and goVersion, determined once per process.
+func ProcessFields() []Field {
+	return append([]Field(nil), processFields()...)
+}
+
+// WithProcessInfo returns a child logger adding ProcessFields to every event.
+func (logger *Logger) WithProcessInfo() *Logger {
+	return logger.With(processFields()...)
+}
+
+var buildFields = sync.OnceValue(func() []Field {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	var fields []Field
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		fields = append(fields, String("app_version", info.Main.Version))
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			fields = append(fields, String("git_sha", setting.Value))
+		case "vcs.modified":
+			fields = append(fields, Bool("git_dirty", setting.Value == "true"))
+		}
+	}
+	return fields
+})
+
+// BuildFields returns app_version, git_sha and git_dirty from the build info of the binary, as far as
+// known. VCS information is only embedded by go build within a repository, not by go run or go test.
+func BuildFields() []Field {
+	return append([]Field(nil), buildFields()...)
+}
+
+// WithBuildInfo returns a child logger adding BuildFields to every event.
+func (logger *Logger) WithBuildInfo() *Logger {
+	return logger.With(buildFields()...)
+}
@@ -0,0 +1,207 @@
+package go_logger
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	dropFiltered    = "filter"
+	dropRateLimited = "rate_limit"
+	dropOverflow    = "overflow"
+	dropSampled     = "sampling"
+)
+
+// latencyBuckets are the upper bounds in seconds of the encode and write latency histograms.
+var latencyBuckets = []float64{1e-6, 5e-6, 1e-5, 5e-5, 1e-4, 5e-4, 1e-3, 5e-3, 1e-2, 5e-2, 0.1, 1}
+
+var metricsEnabled atomic.Bool
+
+type metricKey struct {
+	logger string
+	level  Level
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(d time.Duration) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(latencyBuckets))
+	}
+	seconds := d.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+var metrics struct {
+	mutex   sync.Mutex
+	events  map[metricKey]uint64
+	dropped map[string]uint64
+	encode  histogram
+	write   histogram
+}
+
+// EnableMetrics turns collection of the metrics served by MetricsHandler on or off. Collection is off
+// by default, as the latency histograms take two additional clock readings per event.
+func EnableMetrics(enabled bool) {
+	metricsEnabled.Store(enabled)
+}
+
+func countEvent(event *Event) {
+	if !metricsEnabled.Load() {
+		return
+	}
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	if metrics.events == nil {
+		metrics.events = map[metricKey]uint64{}
+	}
+	metrics.events[metricKey{event.Logger, event.Level}]++
+}
+
+func countDropped(reason string) {
+	if !metricsEnabled.Load() {
+		return
+	}
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	if metrics.dropped == nil {
+		metrics.dropped = map[string]uint64{}
+	}
+	metrics.dropped[reason]++
+}
+
+// observeLatency adds the time since start to h and returns the current time. It returns the zero
+// time if metrics are disabled.
+func observeLatency(h *histogram, start time.Time) time.Time {
+	if start.IsZero() {
+		return start
+	}
+	now := time.Now()
+	metrics.mutex.Lock()
+	h.observe(now.Sub(start))
+	metrics.mutex.Unlock()
+	return now
+}
+
+func metricsStart() time.Time {
+	if !metricsEnabled.Load() {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// MetricsHandler serves the metrics in the Prometheus text exposition format:
+//
+//	log_events_total{logger,level}        events written
+//	log_errors_total{logger}              events written at ERROR or FATAL
+//	log_dropped_events_total{reason}      events dropped by filters, rate limits, full queues or sampling
+//	log_write_errors_total                failed writes
+//	log_encode_duration_seconds           encoding latency histogram of loggers without sinks
+//	log_write_duration_seconds            write latency histogram
+//
+// The handler is a standalone scrape endpoint, not a prometheus.Collector, so that this package does not
+// depend on the Prometheus client. Serve it on a path of its own, e.g. /metrics/log, next to the handler
+// of the application's registry, and let Prometheus scrape both.
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = WriteMetrics(w)
+	})
+}
+
+// WriteMetrics writes the metrics in the Prometheus text exposition format to out.
+func WriteMetrics(out io.Writer) error {
+	sb := buffer{}
+	metrics.mutex.Lock()
+	keys := make([]metricKey, 0, len(metrics.events))
+	for key := range metrics.events {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].logger != keys[j].logger {
+			return keys[i].logger < keys[j].logger
+		}
+		return keys[i].level < keys[j].level
+	})
+	sb.WriteString("# HELP log_events_total Number of log events written.\n# TYPE log_events_total counter\n")
+	errorCounts := map[string]uint64{}
+	for _, key := range keys {
+		writeMetric(&sb, "log_events_total", metrics.events[key], "logger", key.logger, "level", key.level.Long())
+		if key.level >= ERROR {
+			errorCounts[key.logger] += metrics.events[key]
+		}
+	}
+	sb.WriteString("# HELP log_errors_total Number of log events written at ERROR or FATAL.\n# TYPE log_errors_total counter\n")
+	for _, key := range keys {
+		if count, ok := errorCounts[key.logger]; ok {
+			writeMetric(&sb, "log_errors_total", count, "logger", key.logger)
+			delete(errorCounts, key.logger)
+		}
+	}
+	sb.WriteString("# HELP log_dropped_events_total Number of log events dropped.\n# TYPE log_dropped_events_total counter\n")
+	for _, reason := range []string{dropFiltered, dropRateLimited, dropOverflow, dropSampled} {
+		writeMetric(&sb, "log_dropped_events_total", metrics.dropped[reason], "reason", reason)
+	}
+	sb.WriteString("# HELP log_write_errors_total Number of failed writes.\n# TYPE log_write_errors_total counter\n")
+	writeMetric(&sb, "log_write_errors_total", WriteErrors())
+	writeHistogram(&sb, "log_encode_duration_seconds", "Time spent encoding log events.", &metrics.encode)
+	writeHistogram(&sb, "log_write_duration_seconds", "Time spent writing log events.", &metrics.write)
+	metrics.mutex.Unlock()
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
+
+func writeMetric(sb *buffer, name string, value uint64, labels ...string) {
+	sb.WriteString(name)
+	writeMetricLabels(sb, labels...)
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.FormatUint(value, 10))
+	sb.WriteByte('\n')
+}
+
+func writeMetricLabels(sb *buffer, labels ...string) {
+	if len(labels) == 0 {
+		return
+	}
+	sb.WriteByte('{')
+	for i := 0; i+1 < len(labels); i += 2 {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(labels[i])
+		sb.WriteString(`="`)
+		sb.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(labels[i+1]))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+}
+
+func writeHistogram(sb *buffer, name, help string, h *histogram) {
+	sb.WriteString("# HELP " + name + " " + help + "\n# TYPE " + name + " histogram\n")
+	for i, bound := range latencyBuckets {
+		var count uint64
+		if h.counts != nil {
+			count = h.counts[i]
+		}
+		writeMetric(sb, name+"_bucket", count, "le", strconv.FormatFloat(bound, 'g', -1, 64))
+	}
+	writeMetric(sb, name+"_bucket", h.count, "le", "+Inf")
+	sb.WriteString(name + "_sum " + strconv.FormatFloat(h.sum, 'g', -1, 64) + "\n")
+	writeMetric(sb, name+"_count", h.count)
+}
@@ -0,0 +1,136 @@
+package go_logger
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestLogger is net/http middleware logging one event per request with method, path, status, size,
+// duration, remote IP and request ID. The handler gets a request scoped logger via FromContext, and
+// panics are logged with a stack trace and answered with 500.
+type RequestLogger struct {
+	logger      *Logger
+	statusLevel func(status int) Level
+	trustProxy  bool
+}
+
+func NewRequestLogger(logger *Logger) *RequestLogger {
+	return &RequestLogger{logger: logger, statusLevel: DefaultStatusLevel}
+}
+
+// DefaultStatusLevel logs 5xx as ERROR, 4xx as WARN and everything else as INFO.
+func DefaultStatusLevel(status int) Level {
+	switch {
+	case status >= 500:
+		return ERROR
+	case status >= 400:
+		return WARN
+	default:
+		return INFO
+	}
+}
+
+func (requestLogger *RequestLogger) StatusLevel(statusLevel func(status int) Level) *RequestLogger {
+	requestLogger.statusLevel = statusLevel
+	return requestLogger
+}
+
+// TrustProxy takes the remote IP from the first X-Forwarded-For entry if present.
+func (requestLogger *RequestLogger) TrustProxy(trust bool) *RequestLogger {
+	requestLogger.trustProxy = trust
+	return requestLogger
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	if recorder.status == 0 {
+		recorder.status = status
+	}
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *statusRecorder) Write(b []byte) (int, error) {
+	if recorder.status == 0 {
+		recorder.status = http.StatusOK
+	}
+	n, err := recorder.ResponseWriter.Write(b)
+	recorder.size += int64(n)
+	return n, err
+}
+
+func (recorder *statusRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+		flusher.Flush()
+	}
+}
+
+// Unwrap gives http.ResponseController access to the original writer.
+func (recorder *statusRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
+
+func (requestLogger *RequestLogger) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx := ContextFromRequest(r)
+		requestID := RequestIDFromContext(ctx)
+		w.Header().Set(RequestIDHeader, requestID)
+		logger := requestLogger.logger.With(contextFields(ctx)...)
+		r = r.WithContext(ContextWithLogger(ctx, requestLogger.logger))
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+				event := createEvent(ERROR, "panic serving "+r.Method+" "+r.URL.Path, panicError(recovered))
+				event.Stack = captureStack()
+				logger.log(event)
+				if recorder.status == 0 {
+					recorder.WriteHeader(http.StatusInternalServerError)
+				}
+			}
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			event := createEvent(requestLogger.statusLevel(status), r.Method+" "+r.URL.Path+" "+strconv.Itoa(status), nil)
+			event.Fields = append(logger.fields[:len(logger.fields):len(logger.fields)],
+				String("method", r.Method),
+				String("path", r.URL.Path),
+				Int("status", status),
+				Int64("size", recorder.size),
+				Duration("duration", time.Since(start)),
+				String("remoteIp", requestLogger.remoteIP(r)),
+			)
+			logger.log(event)
+		}()
+		next.ServeHTTP(recorder, r)
+	})
+}
+
+func (requestLogger *RequestLogger) remoteIP(r *http.Request) string {
+	if requestLogger.trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			ip, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(ip)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
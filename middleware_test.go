@@ -0,0 +1,114 @@
+package go_logger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestRequestLogger(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		trustProxy bool
+		wantLevel  golog.Level
+		wantMsg    string
+		wantFields map[string]any
+		wantPanic  bool
+	}{
+		{
+			name:       "ok",
+			handler:    func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("hello")) },
+			wantLevel:  golog.INFO,
+			wantMsg:    "GET /items 200",
+			wantFields: map[string]any{"method": "GET", "path": "/items", "status": int64(200), "size": int64(5), "remoteIp": "192.0.2.1", golog.RequestIDKey: "r1"},
+		},
+		{
+			name:       "client error",
+			handler:    func(w http.ResponseWriter, r *http.Request) { http.NotFound(w, r) },
+			wantLevel:  golog.WARN,
+			wantMsg:    "GET /items 404",
+			wantFields: map[string]any{"status": int64(404)},
+		},
+		{
+			name:       "server error",
+			handler:    func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusBadGateway) },
+			wantLevel:  golog.ERROR,
+			wantMsg:    "GET /items 502",
+			wantFields: map[string]any{"status": int64(502), "size": int64(0)},
+		},
+		{
+			name:       "panic",
+			handler:    func(w http.ResponseWriter, r *http.Request) { panic("boom") },
+			wantLevel:  golog.ERROR,
+			wantMsg:    "GET /items 500",
+			wantFields: map[string]any{"status": int64(500)},
+			wantPanic:  true,
+		},
+		{
+			name:       "trusted proxy",
+			handler:    func(w http.ResponseWriter, r *http.Request) {},
+			trustProxy: true,
+			wantLevel:  golog.INFO,
+			wantMsg:    "GET /items 200",
+			wantFields: map[string]any{"remoteIp": "203.0.113.7"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, observer := logtest.NewObservedLogger(golog.TRACE)
+			handler := golog.NewRequestLogger(logger).TrustProxy(tt.trustProxy).Handler(tt.handler)
+			request := httptest.NewRequest(http.MethodGet, "/items", nil)
+			request.RemoteAddr = "192.0.2.1:1234"
+			request.Header.Set(golog.RequestIDHeader, "r1")
+			request.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+
+			if got := response.Header().Get(golog.RequestIDHeader); got != "r1" {
+				t.Errorf("response request ID %q, want r1", got)
+			}
+			events := observer.All()
+			if panics := observer.FilterMessageSnippet("panic serving GET /items").All(); (len(panics) > 0) != tt.wantPanic {
+				t.Errorf("%d panic events, want panic %v", len(panics), tt.wantPanic)
+			} else if tt.wantPanic && (panics[0].Err == nil || panics[0].Stack == nil) {
+				t.Errorf("panic event %+v, want error and stack", panics[0])
+			}
+			access := events[len(events)-1]
+			if access.Level != tt.wantLevel || access.Message != tt.wantMsg {
+				t.Errorf("logged %v %q, want %v %q", access.Level, access.Message, tt.wantLevel, tt.wantMsg)
+			}
+			got := map[string]any{}
+			for _, field := range access.Fields {
+				switch field.Type {
+				case golog.StringType:
+					got[field.Key] = field.String
+				default:
+					got[field.Key] = field.Integer
+				}
+			}
+			for key, value := range tt.wantFields {
+				if got[key] != value {
+					t.Errorf("field %s is %v, want %v", key, got[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestLoggerContext(t *testing.T) {
+	logger, observer := logtest.NewObservedLogger(golog.INFO)
+	handler := golog.NewRequestLogger(logger).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		golog.FromContext(r.Context()).Info("handling")
+	}))
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(golog.RequestIDHeader, "r1")
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	handling := observer.FilterMessage("handling").FilterField(golog.String(golog.RequestIDKey, "r1"))
+	if handling.Len() != 1 {
+		t.Errorf("handler events %v, want one with request_id r1", observer.All())
+	}
+}
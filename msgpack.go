@@ -0,0 +1,393 @@
+package go_logger
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"time"
+)
+
+// Minimal MessagePack writer used by the binary sinks and encoders.
+
+func appendMsgpackNil(buf []byte) []byte { return append(buf, 0xc0) }
+
+func appendMsgpackBool(buf []byte, value bool) []byte {
+	if value {
+		return append(buf, 0xc3)
+	}
+	return append(buf, 0xc2)
+}
+
+func appendMsgpackInt(buf []byte, value int64) []byte {
+	switch {
+	case value >= 0:
+		return appendMsgpackUint(buf, uint64(value))
+	case value >= -32:
+		return append(buf, byte(value))
+	case value >= math.MinInt8:
+		return append(buf, 0xd0, byte(value))
+	case value >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xd1), uint16(value))
+	case value >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(buf, 0xd2), uint32(value))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, 0xd3), uint64(value))
+	}
+}
+
+func appendMsgpackUint(buf []byte, value uint64) []byte {
+	switch {
+	case value <= 0x7f:
+		return append(buf, byte(value))
+	case value <= math.MaxUint8:
+		return append(buf, 0xcc, byte(value))
+	case value <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xcd), uint16(value))
+	case value <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, 0xce), uint32(value))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, 0xcf), value)
+	}
+}
+
+func appendMsgpackFloat(buf []byte, value float64) []byte {
+	return binary.BigEndian.AppendUint64(append(buf, 0xcb), math.Float64bits(value))
+}
+
+func appendMsgpackString(buf []byte, value string) []byte {
+	n := len(value)
+	switch {
+	case n <= 31:
+		buf = append(buf, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		buf = append(buf, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		buf = binary.BigEndian.AppendUint16(append(buf, 0xda), uint16(n))
+	default:
+		buf = binary.BigEndian.AppendUint32(append(buf, 0xdb), uint32(n))
+	}
+	return append(buf, value...)
+}
+
+func appendMsgpackArrayHeader(buf []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(buf, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xdc), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(buf, 0xdd), uint32(n))
+	}
+}
+
+func appendMsgpackMapHeader(buf []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(buf, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xde), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(buf, 0xdf), uint32(n))
+	}
+}
+
+// appendMsgpackTime writes the timestamp extension type -1 with 64 bit seconds and nanoseconds.
+func appendMsgpackTime(buf []byte, t time.Time) []byte {
+	buf = append(buf, 0xc7, 12, 0xff)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(t.Nanosecond()))
+	return binary.BigEndian.AppendUint64(buf, uint64(t.Unix()))
+}
+
+func (field Field) appendMsgpack(buf []byte) []byte {
+	switch field.Type {
+	case StringType:
+		return appendMsgpackString(buf, field.String)
+	case IntType:
+		return appendMsgpackInt(buf, field.Integer)
+	case UintType:
+		return appendMsgpackUint(buf, uint64(field.Integer))
+	case FloatType:
+		return appendMsgpackFloat(buf, math.Float64frombits(uint64(field.Integer)))
+	case BoolType:
+		return appendMsgpackBool(buf, field.Integer != 0)
+	case DurationType:
+		return appendMsgpackString(buf, time.Duration(field.Integer).String())
+	case TimeType:
+		return appendMsgpackTime(buf, field.time())
+	case ErrorType:
+		if err, ok := field.Interface.(error); ok && err != nil {
+			return appendMsgpackString(buf, err.Error())
+		}
+		return appendMsgpackNil(buf)
+	case ObjectType:
+		fields, _ := field.Interface.([]Field)
+		buf = appendMsgpackMapHeader(buf, len(fields))
+		for _, f := range fields {
+			buf = appendMsgpackString(buf, f.Key)
+			buf = f.appendMsgpack(buf)
+		}
+		return buf
+	case ArrayType:
+		fields, _ := field.Interface.([]Field)
+		buf = appendMsgpackArrayHeader(buf, len(fields))
+		for _, f := range fields {
+			buf = f.appendMsgpack(buf)
+		}
+		return buf
+	default:
+		if field.Interface == nil {
+			return appendMsgpackNil(buf)
+		}
+		text := buffer{}
+		field.writeText(&text)
+		return appendMsgpackString(buf, text.String())
+	}
+}
+
+var errMsgpackUnsupported = errors.New("go_logger: unsupported msgpack type")
+
+// readMsgpackStringMap reads a map with string keys and string values, as sent by fluentd acks.
+func readMsgpackStringMap(r io.Reader) (map[string]string, error) {
+	var head [1]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+	var n int
+	switch {
+	case head[0]&0xf0 == 0x80:
+		n = int(head[0] & 0x0f)
+	case head[0] == 0xde:
+		var size [2]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return nil, err
+		}
+		n = int(binary.BigEndian.Uint16(size[:]))
+	default:
+		return nil, errMsgpackUnsupported
+	}
+	result := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		key, err := readMsgpackString(r)
+		if err != nil {
+			return nil, err
+		}
+		value, err := readMsgpackString(r)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+func readMsgpackString(r io.Reader) (string, error) {
+	var head [1]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return "", err
+	}
+	var n int
+	switch {
+	case head[0]&0xe0 == 0xa0:
+		n = int(head[0] & 0x1f)
+	case head[0] == 0xd9 || head[0] == 0xc4:
+		var size [1]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return "", err
+		}
+		n = int(size[0])
+	case head[0] == 0xda || head[0] == 0xc5:
+		var size [2]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return "", err
+		}
+		n = int(binary.BigEndian.Uint16(size[:]))
+	case head[0] == 0xdb || head[0] == 0xc6:
+		var size [4]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return "", err
+		}
+		n = int(binary.BigEndian.Uint32(size[:]))
+	default:
+		return "", errMsgpackUnsupported
+	}
+	value := make([]byte, n)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// readMsgpackValue reads one value as a field with the given key. Maps become objects, arrays arrays
+// and timestamp extensions times; binary data is read as a string and other extensions are skipped.
+func readMsgpackValue(r *bufio.Reader, key string, depth int) (Field, error) {
+	head, err := r.ReadByte()
+	if err != nil {
+		if err == io.EOF && depth > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return Field{}, err
+	}
+	if depth > maxDecodeDepth {
+		return Field{}, errDecodeDepth
+	}
+	switch {
+	case head <= 0x7f:
+		return Int64(key, int64(head)), nil
+	case head >= 0xe0:
+		return Int64(key, int64(int8(head))), nil
+	case head&0xf0 == 0x80:
+		return readMsgpackMap(r, key, uint64(head&0x0f), depth)
+	case head&0xf0 == 0x90:
+		return readMsgpackArray(r, key, uint64(head&0x0f), depth)
+	case head&0xe0 == 0xa0:
+		return readMsgpackStr(r, key, uint64(head&0x1f))
+	}
+	switch head {
+	case 0xc0:
+		return Field{Key: key, Type: AnyType}, nil
+	case 0xc2, 0xc3:
+		return Bool(key, head == 0xc3), nil
+	case 0xca:
+		b, err := readDecodeBytes(r, 4)
+		if err != nil {
+			return Field{}, err
+		}
+		return Float64(key, float64(math.Float32frombits(binary.BigEndian.Uint32(b)))), nil
+	case 0xcb:
+		b, err := readDecodeBytes(r, 8)
+		if err != nil {
+			return Field{}, err
+		}
+		return Float64(key, math.Float64frombits(binary.BigEndian.Uint64(b))), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := readMsgpackLength(r, 1<<(head-0xcc))
+		if err != nil {
+			return Field{}, err
+		}
+		if n > math.MaxInt64 {
+			return Uint64(key, n), nil
+		}
+		return Int64(key, int64(n)), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (head - 0xd0)
+		n, err := readMsgpackLength(r, size)
+		if err != nil {
+			return Field{}, err
+		}
+		shift := 64 - 8*size
+		return Int64(key, int64(n<<shift)>>shift), nil
+	case 0xc4, 0xc5, 0xc6, 0xd9, 0xda, 0xdb:
+		size := 1 << (head - 0xc4)
+		if head >= 0xd9 {
+			size = 1 << (head - 0xd9)
+		}
+		n, err := readMsgpackLength(r, size)
+		if err != nil {
+			return Field{}, err
+		}
+		return readMsgpackStr(r, key, n)
+	case 0xdc, 0xdd:
+		n, err := readMsgpackLength(r, 2<<(head-0xdc))
+		if err != nil {
+			return Field{}, err
+		}
+		return readMsgpackArray(r, key, n, depth)
+	case 0xde, 0xdf:
+		n, err := readMsgpackLength(r, 2<<(head-0xde))
+		if err != nil {
+			return Field{}, err
+		}
+		return readMsgpackMap(r, key, n, depth)
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return readMsgpackExt(r, key, 1<<(head-0xd4))
+	case 0xc7, 0xc8, 0xc9:
+		n, err := readMsgpackLength(r, 1<<(head-0xc7))
+		if err != nil {
+			return Field{}, err
+		}
+		return readMsgpackExt(r, key, n)
+	default:
+		return Field{}, errMsgpackUnsupported
+	}
+}
+
+func readMsgpackLength(r *bufio.Reader, size int) (uint64, error) {
+	b, err := readDecodeBytes(r, uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n, nil
+}
+
+func readMsgpackStr(r *bufio.Reader, key string, n uint64) (Field, error) {
+	b, err := readDecodeBytes(r, n)
+	if err != nil {
+		return Field{}, err
+	}
+	return String(key, string(b)), nil
+}
+
+func readMsgpackArray(r *bufio.Reader, key string, n uint64, depth int) (Field, error) {
+	if n > maxDecodeLength {
+		return Field{}, errDecodeLength
+	}
+	values := make([]Field, 0, min(n, 64))
+	for i := uint64(0); i < n; i++ {
+		value, err := readMsgpackValue(r, "", depth+1)
+		if err != nil {
+			return Field{}, err
+		}
+		values = append(values, value)
+	}
+	return Array(key, values...), nil
+}
+
+func readMsgpackMap(r *bufio.Reader, key string, n uint64, depth int) (Field, error) {
+	if n > maxDecodeLength {
+		return Field{}, errDecodeLength
+	}
+	fields := make([]Field, 0, min(n, 64))
+	for i := uint64(0); i < n; i++ {
+		k, err := readMsgpackValue(r, "", depth+1)
+		if err != nil {
+			return Field{}, err
+		}
+		value, err := readMsgpackValue(r, k.text(), depth+1)
+		if err != nil {
+			return Field{}, err
+		}
+		fields = append(fields, value)
+	}
+	return Object(key, fields...), nil
+}
+
+// readMsgpackExt reads an extension with n data bytes. Timestamps (type -1) become times, other
+// types are kept as null.
+func readMsgpackExt(r *bufio.Reader, key string, n uint64) (Field, error) {
+	b, err := readDecodeBytes(r, n+1)
+	if err != nil {
+		return Field{}, err
+	}
+	typ, data := int8(b[0]), b[1:]
+	if typ != -1 {
+		return Field{Key: key, Type: AnyType}, nil
+	}
+	switch len(data) {
+	case 4:
+		return Time(key, time.Unix(int64(binary.BigEndian.Uint32(data)), 0)), nil
+	case 8:
+		value := binary.BigEndian.Uint64(data)
+		return Time(key, time.Unix(int64(value&(1<<34-1)), int64(value>>34))), nil
+	case 12:
+		nanos := binary.BigEndian.Uint32(data)
+		return Time(key, time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(nanos))), nil
+	default:
+		return Field{}, errMsgpackUnsupported
+	}
+}
@@ -0,0 +1,45 @@
+package go_logger
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Multiline selects how messages spanning several lines are written.
+type Multiline uint8
+
+const (
+	// MultilineEscape writes newlines in messages as \n, keeping every event on a single line.
+	MultilineEscape Multiline = iota
+	// MultilineIndent keeps newlines and indents continuation lines under the header in PLAIN output,
+	// e.g. for stack traces. JSON output carries the real newlines as string escapes.
+	MultilineIndent
+	// MultilineKeep writes messages unchanged, in PLAIN output with bare newlines.
+	MultilineKeep
+)
+
+// Multiline sets how multi-line messages are written, MultilineEscape by default. Sinks with their own
+// PlainEncoder use its Multiline setting.
+func (logger *Logger) Multiline(mode Multiline) *Logger {
+	logger = logger.derive()
+	logger.multiline = mode
+	return logger
+}
+
+// writeMessage writes msg according to the Multiline setting. Continuation lines are indented by the
+// visible width of everything written since start.
+func (encoder *PlainEncoder) writeMessage(sb *buffer, msg string, start int) {
+	if strings.IndexByte(msg, '\n') < 0 {
+		sb.WriteString(msg)
+		return
+	}
+	switch encoder.Multiline {
+	case MultilineEscape:
+		sb.WriteString(strings.ReplaceAll(msg, "\n", "\\n"))
+	case MultilineIndent:
+		header := stripColors([]byte(sb.String()[start:]))
+		sb.WriteString(strings.ReplaceAll(msg, "\n", "\n"+strings.Repeat(" ", utf8.RuneCount(header))))
+	default:
+		sb.WriteString(msg)
+	}
+}
@@ -0,0 +1,72 @@
+package go_logger_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestMultilinePlain(t *testing.T) {
+	event := &golog.Event{Timestamp: time.Now(), Level: golog.INFO, Logger: "app", Message: "first\nsecond"}
+	tests := []struct {
+		name      string
+		mode      golog.Multiline
+		wantLines int
+		check     func(lines []string) bool
+	}{
+		{
+			name:      "escape",
+			mode:      golog.MultilineEscape,
+			wantLines: 1,
+			check:     func(lines []string) bool { return strings.HasSuffix(lines[0], `first\nsecond`) },
+		},
+		{
+			name:      "indent",
+			mode:      golog.MultilineIndent,
+			wantLines: 2,
+			check: func(lines []string) bool {
+				return strings.HasSuffix(lines[0], "first") &&
+					lines[1] == strings.Repeat(" ", len(lines[0])-len("first"))+"second"
+			},
+		},
+		{
+			name:      "keep",
+			mode:      golog.MultilineKeep,
+			wantLines: 2,
+			check:     func(lines []string) bool { return lines[1] == "second" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder := golog.NewPlainEncoder(false)
+			encoder.Multiline = tt.mode
+			sb := strings.Builder{}
+			encoder.Encode(&sb, event)
+			lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+			if len(lines) != tt.wantLines || !tt.check(lines) {
+				t.Errorf("encoded %q", sb.String())
+			}
+		})
+	}
+}
+
+func TestMultilineLogger(t *testing.T) {
+	tests := []struct {
+		mode golog.Multiline
+		want string
+	}{
+		{golog.MultilineEscape, `first\nsecond`},
+		{golog.MultilineIndent, "first\nsecond"},
+		{golog.MultilineKeep, "first\nsecond"},
+	}
+	for _, tt := range tests {
+		logger, observer := logtest.NewObservedLogger(golog.INFO)
+		logger.Multiline(tt.mode).Info("first\nsecond")
+		if got := observer.All()[0].Message; got != tt.want {
+			t.Errorf("Multiline(%d) logged %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package go_logger
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NetworkSink writes encoded events to a tcp://, udp:// or unix:// address. While the connection is
+// down, events are kept in a bounded spill buffer (dropping the oldest when full) and written first once
+// a reconnect succeeds. Reconnects are attempted on write, at most once per reconnect interval. A message
+// that was only partly written is resumed where it stopped, so no part of a line is sent twice.
+type NetworkSink struct {
+	mutex             sync.Mutex
+	network           string
+	address           string
+	encoder           Encoder
+	level             Level
+	conn              net.Conn
+	writeTimeout      time.Duration
+	reconnectInterval time.Duration
+	lastAttempt       time.Time
+	spill             [][]byte
+	spillBytes        int
+	spillWritten      int
+	maxSpillBytes     int
+	dropped           atomic.Uint64
+	closed            bool
+}
+
+// NewNetworkSink parses a URL like tcp://logstash:5000 or unix:///var/run/collector.sock. The connection
+// is established lazily, so the collector does not need to be up at startup.
+func NewNetworkSink(rawURL string, encoder Encoder) (*NetworkSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	sink := &NetworkSink{
+		network:           u.Scheme,
+		encoder:           encoder,
+		level:             TRACE,
+		writeTimeout:      5 * time.Second,
+		reconnectInterval: time.Second,
+		maxSpillBytes:     4 << 20,
+	}
+	switch u.Scheme {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+		sink.address = u.Host
+	case "unix", "unixgram":
+		sink.address = u.Path
+	default:
+		return nil, fmt.Errorf("go_logger: unsupported network %q", u.Scheme)
+	}
+	return sink, nil
+}
+
+func (sink *NetworkSink) Level(level Level) *NetworkSink {
+	sink.level = level
+	return sink
+}
+func (sink *NetworkSink) WriteTimeout(timeout time.Duration) *NetworkSink {
+	sink.writeTimeout = timeout
+	return sink
+}
+func (sink *NetworkSink) ReconnectInterval(interval time.Duration) *NetworkSink {
+	sink.reconnectInterval = interval
+	return sink
+}
+
+// MaxSpillBytes limits the spill buffer. Zero disables spilling, events are dropped while disconnected.
+func (sink *NetworkSink) MaxSpillBytes(size int) *NetworkSink {
+	sink.maxSpillBytes = size
+	return sink
+}
+
+// Dropped returns the number of events lost because the spill buffer was full.
+func (sink *NetworkSink) Dropped() uint64 {
+	return sink.dropped.Load()
+}
+
+func (sink *NetworkSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	buf := encodeEvent(sink.encoder, event)
+	defer putBuffer(buf)
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.closed {
+		return ErrSinkClosed
+	}
+	// drain first, so the spill buffer has room and a message too large for it can be written directly
+	err := sink.drain()
+	buffered := sink.spillMessage(append([]byte(nil), *buf...))
+	if err == nil {
+		err = sink.drain()
+	}
+	if err != nil && !buffered {
+		return err
+	}
+	return nil
+}
+
+func (sink *NetworkSink) connect() error {
+	if sink.conn != nil {
+		return nil
+	}
+	if time.Since(sink.lastAttempt) < sink.reconnectInterval {
+		return errNotConnected
+	}
+	sink.lastAttempt = time.Now()
+	conn, err := net.DialTimeout(sink.network, sink.address, sink.writeTimeout)
+	if err != nil {
+		return err
+	}
+	sink.conn = conn
+	return nil
+}
+
+var errNotConnected = errors.New("go_logger: not connected, waiting to reconnect")
+
+// drain writes the spill buffer to the connection. Messages stay buffered if that fails, together with
+// the number of bytes of the first one already written. The connection is only kept after a timeout.
+func (sink *NetworkSink) drain() error {
+	if err := sink.connect(); err != nil {
+		return err
+	}
+	for len(sink.spill) > 0 {
+		message := sink.spill[0]
+		if sink.writeTimeout > 0 {
+			_ = sink.conn.SetWriteDeadline(time.Now().Add(sink.writeTimeout))
+		}
+		n, err := sink.conn.Write(message[sink.spillWritten:])
+		sink.spillWritten += n
+		if err != nil {
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				_ = sink.conn.Close()
+				sink.conn = nil
+			}
+			return err
+		}
+		sink.dropFirst()
+	}
+	return nil
+}
+
+// dropFirst removes the first message from the spill buffer.
+func (sink *NetworkSink) dropFirst() {
+	sink.spillBytes -= len(sink.spill[0])
+	sink.spill[0] = nil
+	sink.spill = sink.spill[1:]
+	sink.spillWritten = 0
+}
+
+// spillMessage appends message to the spill buffer and reports whether it could be kept.
+func (sink *NetworkSink) spillMessage(message []byte) bool {
+	if len(message) > sink.maxSpillBytes && len(sink.spill) == 0 && sink.conn != nil {
+		// too large to buffer, but may still be written directly
+		sink.spill = append(sink.spill, message)
+		sink.spillBytes += len(message)
+		return false
+	}
+	for len(sink.spill) > 0 && sink.spillBytes+len(message) > sink.maxSpillBytes {
+		sink.dropFirst()
+		sink.dropped.Add(1)
+	}
+	if len(message) > sink.maxSpillBytes {
+		sink.dropped.Add(1)
+		return false
+	}
+	sink.spill = append(sink.spill, message)
+	sink.spillBytes += len(message)
+	return true
+}
+
+// Flush tries to write the spill buffer.
+func (sink *NetworkSink) Flush() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.closed || len(sink.spill) == 0 {
+		return nil
+	}
+	sink.lastAttempt = time.Time{}
+	return sink.drain()
+}
+
+func (sink *NetworkSink) Close() error {
+	err := sink.Flush()
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.closed = true
+	if sink.conn != nil {
+		if closeErr := sink.conn.Close(); err == nil {
+			err = closeErr
+		}
+		sink.conn = nil
+	}
+	return err
+}
@@ -0,0 +1,140 @@
+package go_logger_test
+
+import (
+	"bufio"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+// collector accepts tcp connections on address and sends the lines it receives to lines.
+func collector(t *testing.T, address string, lines chan<- string) net.Listener {
+	t.Helper()
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					lines <- scanner.Text()
+				}
+			}()
+		}
+	}()
+	return listener
+}
+
+// freeAddress returns a local tcp address nobody listens on.
+func freeAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+	return address
+}
+
+func receive(t *testing.T, lines <-chan string, n int) []string {
+	t.Helper()
+	var received []string
+	for len(received) < n {
+		select {
+		case line := <-lines:
+			received = append(received, line)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %q, want %d lines", received, n)
+		}
+	}
+	return received
+}
+
+func TestNetworkSink(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxSpill    int
+		down        []string
+		up          []string
+		wantErr     bool
+		want        []string
+		wantDropped uint64
+	}{
+		{
+			name:     "connected",
+			maxSpill: 1 << 10,
+			up:       []string{"1", "2", "3"},
+			want:     []string{"1", "2", "3"},
+		},
+		{
+			name: "spill disabled",
+			up:   []string{"1", "2", "3"},
+			want: []string{"1", "2", "3"},
+		},
+		{
+			name:     "spilled while down",
+			maxSpill: 1 << 10,
+			down:     []string{"1", "2"},
+			up:       []string{"3"},
+			want:     []string{"1", "2", "3"},
+		},
+		{
+			name:        "spill full while down",
+			maxSpill:    4,
+			down:        []string{"1", "2", "3"},
+			up:          []string{"4"},
+			want:        []string{"2", "3", "4"},
+			wantDropped: 1,
+		},
+		{
+			name:        "spill disabled while down",
+			down:        []string{"1"},
+			up:          []string{"2", "3"},
+			wantErr:     true,
+			want:        []string{"2", "3"},
+			wantDropped: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address := freeAddress(t)
+			sink, err := golog.NewNetworkSink("tcp://"+address, golog.MustPatternEncoder("%m"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sink.MaxSpillBytes(tt.maxSpill).ReconnectInterval(0).WriteTimeout(time.Second)
+			defer sink.Close()
+			for _, msg := range tt.down {
+				err := sink.Write(&golog.Event{Level: golog.INFO, Message: msg})
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Write(%q) while down = %v, want error %v", msg, err, tt.wantErr)
+				}
+			}
+			lines := make(chan string, 16)
+			collector(t, address, lines)
+			for _, msg := range tt.up {
+				if err := sink.Write(&golog.Event{Level: golog.INFO, Message: msg}); err != nil {
+					t.Fatalf("Write(%q) = %v", msg, err)
+				}
+			}
+			if got := receive(t, lines, len(tt.want)); !slices.Equal(got, tt.want) {
+				t.Errorf("received %q, want %q", got, tt.want)
+			}
+			if got := sink.Dropped(); got != tt.wantDropped {
+				t.Errorf("Dropped() = %d, want %d", got, tt.wantDropped)
+			}
+		})
+	}
+}
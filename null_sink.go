@@ -0,0 +1,66 @@
+package go_logger
+
+import "sync/atomic"
+
+// Discard is a sink that drops every event without encoding it, e.g. to benchmark the logger itself or
+// to silence a logger that must have a sink.
+var Discard Sink = discardSink{}
+
+type discardSink struct{}
+
+func (discardSink) Write(*Event) error { return nil }
+func (discardSink) Flush() error       { return nil }
+func (discardSink) Close() error       { return nil }
+
+// CountingSink only counts the events it receives per level, for metrics without log volume or to
+// check in benchmarks how many events got through.
+type CountingSink struct {
+	counts [levelOff]atomic.Uint64
+}
+
+func NewCountingSink() *CountingSink {
+	return &CountingSink{}
+}
+
+func (sink *CountingSink) Write(event *Event) error {
+	if event.Level >= TRACE && event.Level < levelOff {
+		sink.counts[event.Level].Add(1)
+	}
+	return nil
+}
+
+func (sink *CountingSink) Flush() error { return nil }
+func (sink *CountingSink) Close() error { return nil }
+
+// Count returns the number of events counted at level.
+func (sink *CountingSink) Count(level Level) uint64 {
+	if level < TRACE || level >= levelOff {
+		return 0
+	}
+	return sink.counts[level].Load()
+}
+
+// Counts returns the numbers of events counted per level, including levels without events.
+func (sink *CountingSink) Counts() map[Level]uint64 {
+	counts := make(map[Level]uint64, len(sink.counts))
+	for level := range sink.counts {
+		counts[Level(level)] = sink.counts[level].Load()
+	}
+	return counts
+}
+
+// Total returns the number of events counted at all levels.
+func (sink *CountingSink) Total() uint64 {
+	total := uint64(0)
+	for level := range sink.counts {
+		total += sink.counts[level].Load()
+	}
+	return total
+}
+
+// Reset sets all counts to zero.
+func (sink *CountingSink) Reset() {
+	for level := range sink.counts {
+		sink.counts[level].Store(0)
+	}
+}
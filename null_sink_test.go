@@ -0,0 +1,64 @@
+package go_logger_test
+
+import (
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestCountingSink(t *testing.T) {
+	tests := []struct {
+		name   string
+		level  golog.Level
+		logged []golog.Level
+		want   map[golog.Level]uint64
+	}{
+		{name: "none", level: golog.TRACE, want: map[golog.Level]uint64{}},
+		{
+			name:   "per level",
+			level:  golog.TRACE,
+			logged: []golog.Level{golog.INFO, golog.ERROR, golog.INFO, golog.TRACE},
+			want:   map[golog.Level]uint64{golog.TRACE: 1, golog.INFO: 2, golog.ERROR: 1},
+		},
+		{
+			name:   "below logger level",
+			level:  golog.WARN,
+			logged: []golog.Level{golog.DEBUG, golog.WARN, golog.INFO},
+			want:   map[golog.Level]uint64{golog.WARN: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := golog.NewCountingSink()
+			logger := golog.NewLogger("counting").Level(tt.level).Sinks(sink)
+			for _, level := range tt.logged {
+				logger.Log(level, "m")
+			}
+			total := uint64(0)
+			for level, count := range sink.Counts() {
+				if count != tt.want[level] {
+					t.Errorf("counted %d %v events, want %d", count, level, tt.want[level])
+				}
+				if sink.Count(level) != count {
+					t.Errorf("Count(%v) = %d, Counts has %d", level, sink.Count(level), count)
+				}
+				total += count
+			}
+			if sink.Total() != total {
+				t.Errorf("Total = %d, want %d", sink.Total(), total)
+			}
+			sink.Reset()
+			if sink.Total() != 0 {
+				t.Errorf("Total = %d after Reset", sink.Total())
+			}
+		})
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	logger := golog.NewLogger("discard").Level(golog.TRACE).Sinks(golog.Discard)
+	logger.Info("dropped")
+	if err := logger.Flush(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,112 @@
+package go_logger
+
+import (
+	"io"
+	"time"
+)
+
+// Option configures a logger created with New or derived with WithOptions. Options are plain values
+// and may be shared between goroutines and reused for any number of loggers.
+type Option func(logger *Logger) *Logger
+
+// New creates a logger like NewLogger and applies opts in order.
+func New(name string, opts ...Option) *Logger {
+	logger := NewLogger(name)
+	for _, opt := range opts {
+		logger = opt(logger)
+	}
+	return logger
+}
+
+// WithOptions returns a copy of the logger with opts applied, leaving the logger itself unchanged.
+// The copy gets its own level.
+func (logger *Logger) WithOptions(opts ...Option) *Logger {
+	child := logger.Clone()
+	for _, opt := range opts {
+		child = opt(child)
+	}
+	return child
+}
+
+func WithLevel(level Level) Option {
+	return func(logger *Logger) *Logger { return logger.Level(level) }
+}
+func WithOutput(out io.Writer) Option {
+	return func(logger *Logger) *Logger { return logger.Out(out) }
+}
+func WithErrorOutput(out io.Writer) Option {
+	return func(logger *Logger) *Logger { return logger.ErrorOut(out) }
+}
+func WithSplitStd() Option {
+	return func(logger *Logger) *Logger { return logger.SplitStd() }
+}
+func WithFormat(format Format) Option {
+	return func(logger *Logger) *Logger { return logger.Format(format) }
+}
+func WithEncoder(encoder Encoder) Option {
+	return func(logger *Logger) *Logger { return logger.Encoder(encoder) }
+}
+func WithColors(colorized bool) Option {
+	return func(logger *Logger) *Logger { return logger.Colorized(colorized) }
+}
+func WithCaller(caller bool) Option {
+	return func(logger *Logger) *Logger { return logger.WithCaller(caller) }
+}
+func WithCallerSkip(skip int) Option {
+	return func(logger *Logger) *Logger { return logger.CallerSkip(skip) }
+}
+func WithStackTrace(level Level) Option {
+	return func(logger *Logger) *Logger { return logger.StackTraceAt(level) }
+}
+
+// WithFields adds fields to every event, like Logger.With.
+func WithFields(fields ...Field) Option {
+	return func(logger *Logger) *Logger { return logger.With(fields...) }
+}
+
+// WithSinks replaces the sinks of the logger.
+func WithSinks(sinks ...Sink) Option {
+	return func(logger *Logger) *Logger { return logger.Sinks(sinks...) }
+}
+func WithClock(clock Clock) Option {
+	return func(logger *Logger) *Logger { return logger.Clock(clock) }
+}
+func WithTimestampFormat(layout string) Option {
+	return func(logger *Logger) *Logger { return logger.TimestampFormat(layout) }
+}
+func WithTimeZone(loc *time.Location) Option {
+	return func(logger *Logger) *Logger { return logger.TimeZone(loc) }
+}
+func WithRedactors(redactors ...Redactor) Option {
+	return func(logger *Logger) *Logger { return logger.Redact(redactors...) }
+}
+func WithMaxMessageLength(n int) Option {
+	return func(logger *Logger) *Logger { return logger.MaxMessageLength(n) }
+}
+func WithMultiline(mode Multiline) Option {
+	return func(logger *Logger) *Logger { return logger.Multiline(mode) }
+}
+func WithBinarySafe(mode BinaryMode) Option {
+	return func(logger *Logger) *Logger { return logger.BinarySafe(mode) }
+}
+func WithFilter(filter func(*Event) bool) Option {
+	return func(logger *Logger) *Logger { return logger.Filter(filter) }
+}
+func WithRateLimit(events int, per time.Duration) Option {
+	return func(logger *Logger) *Logger { return logger.RateLimit(events, per) }
+}
+func WithPanicOnFatal(panicOnFatal bool) Option {
+	return func(logger *Logger) *Logger { return logger.PanicOnFatal(panicOnFatal) }
+}
+func WithExitOnFatal(code int) Option {
+	return func(logger *Logger) *Logger { return logger.ExitOnFatal(code) }
+}
+func WithOnPanic(level Level, repanic bool) Option {
+	return func(logger *Logger) *Logger { return logger.OnPanic(level, repanic) }
+}
+func WithFlightRecorder(recorder *FlightRecorder) Option {
+	return func(logger *Logger) *Logger { return logger.Record(recorder) }
+}
+func WithEscalation(trigger Level, level Level, duration time.Duration, events int) Option {
+	return func(logger *Logger) *Logger { return logger.EscalateOn(trigger, level, duration, events) }
+}
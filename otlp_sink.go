@@ -0,0 +1,309 @@
+package go_logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OTLPSink exports batches of events as OpenTelemetry log records over OTLP/HTTP with JSON encoding.
+// The logger name becomes the instrumentation scope, the level the severity and fields become
+// attributes; trace_id and span_id fields set the trace context of the record. OTLP over gRPC is not
+// supported, use the HTTP endpoint of the collector, usually port 4318.
+type OTLPSink struct {
+	url        string
+	client     *http.Client
+	header     http.Header
+	resource   []Field
+	level      Level
+	compress   bool
+	maxRetries int
+	backoff    time.Duration
+	batcher    *batcher
+	onError    func(error, []*Event)
+}
+
+// NewOTLPSink exports to endpoint, e.g. "http://localhost:4318". The path /v1/logs is appended unless
+// endpoint already has a path.
+func NewOTLPSink(endpoint string, batchSize int, interval time.Duration) *OTLPSink {
+	url := strings.TrimSuffix(endpoint, "/")
+	if scheme := strings.Index(url, "://"); scheme < 0 || !strings.Contains(url[scheme+3:], "/") {
+		url += "/v1/logs"
+	}
+	sink := &OTLPSink{
+		url:        url,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		header:     http.Header{},
+		level:      TRACE,
+		maxRetries: 3,
+		backoff:    500 * time.Millisecond,
+	}
+	sink.batcher = newBatcher(batchSize, interval, sink.send)
+	sink.batcher.onError = func(err error, events []*Event) {
+		if sink.onError != nil {
+			sink.onError(err, events)
+		}
+	}
+	return sink
+}
+
+func (sink *OTLPSink) Client(client *http.Client) *OTLPSink {
+	sink.client = client
+	return sink
+}
+func (sink *OTLPSink) Header(key, value string) *OTLPSink {
+	sink.header.Set(key, value)
+	return sink
+}
+func (sink *OTLPSink) Level(level Level) *OTLPSink {
+	sink.level = level
+	return sink
+}
+
+// Resource sets the resource attributes, e.g. String("service.name", "api").
+func (sink *OTLPSink) Resource(attributes ...Field) *OTLPSink {
+	sink.resource = attributes
+	return sink
+}
+func (sink *OTLPSink) Compress(compress bool) *OTLPSink {
+	sink.compress = compress
+	return sink
+}
+func (sink *OTLPSink) Retry(maxRetries int, backoff time.Duration) *OTLPSink {
+	sink.maxRetries = maxRetries
+	sink.backoff = backoff
+	return sink
+}
+
+// OnError is called with batches that could not be exported after all retries, and with ErrBatchDropped
+// for batches dropped because sending could not keep up.
+func (sink *OTLPSink) OnError(callback func(error, []*Event)) *OTLPSink {
+	sink.onError = callback
+	return sink
+}
+
+func (sink *OTLPSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	return sink.batcher.add(event)
+}
+
+func (sink *OTLPSink) send(events []*Event) error {
+	body, err := sink.body(events)
+	if err != nil {
+		return err
+	}
+	backoff := sink.backoff
+	for attempt := 0; ; attempt++ {
+		err = sink.post(body)
+		if err == nil || !isRetryable(err) || attempt >= sink.maxRetries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (sink *OTLPSink) post(body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, sink.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range sink.header {
+		request.Header[key] = values
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if sink.compress {
+		request.Header.Set("Content-Encoding", "gzip")
+	}
+	response, err := sink.client.Do(request)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+	if response.StatusCode >= 300 {
+		return statusError(response.StatusCode)
+	}
+	return nil
+}
+
+// body encodes events as ExportLogsServiceRequest, grouping consecutive events of a logger into one scope.
+func (sink *OTLPSink) body(events []*Event) ([]byte, error) {
+	sb := buffer{}
+	sb.WriteString(`{"resourceLogs":[{"resource":{"attributes":`)
+	writeOTLPAttributes(&sb, sink.resource)
+	sb.WriteString(`},"scopeLogs":[`)
+	for i, event := range events {
+		if i == 0 || event.Logger != events[i-1].Logger {
+			if i > 0 {
+				sb.WriteString(`]},`)
+			}
+			sb.WriteString(`{"scope":{"name":`)
+			writeJSONString(&sb, event.Logger)
+			sb.WriteString(`},"logRecords":[`)
+		} else {
+			sb.WriteByte(',')
+		}
+		writeOTLPRecord(&sb, event)
+	}
+	if len(events) > 0 {
+		sb.WriteString(`]}`)
+	}
+	sb.WriteString(`]}]}`)
+	if !sink.compress {
+		return []byte(sb.String()), nil
+	}
+	buf := bytes.Buffer{}
+	gz := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(gz, sb.String()); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// OTLPSeverity maps a level to the OpenTelemetry severity number.
+func OTLPSeverity(level Level) int {
+	switch level {
+	case TRACE:
+		return 1
+	case DEBUG:
+		return 5
+	case INFO:
+		return 9
+	case WARN:
+		return 13
+	case ERROR:
+		return 17
+	default:
+		return 21
+	}
+}
+
+func writeOTLPRecord(sb *buffer, event *Event) {
+	timestamp := strconv.FormatInt(event.Timestamp.UnixNano(), 10)
+	sb.WriteString(`{"timeUnixNano":"`)
+	sb.WriteString(timestamp)
+	sb.WriteString(`","observedTimeUnixNano":"`)
+	sb.WriteString(timestamp)
+	sb.WriteString(`","severityNumber":`)
+	sb.WriteString(strconv.Itoa(OTLPSeverity(event.Level)))
+	sb.WriteString(`,"severityText":"`)
+	sb.WriteString(event.Level.Long())
+	sb.WriteString(`","body":{"stringValue":`)
+	writeJSONString(sb, event.Message)
+	sb.WriteByte('}')
+	attributes := make([]Field, 0, len(event.Fields)+5)
+	var traceID, spanID string
+	for _, field := range event.Fields {
+		switch {
+		case field.Key == TraceIDKey && field.Type == StringType:
+			traceID = field.String
+		case field.Key == SpanIDKey && field.Type == StringType:
+			spanID = field.String
+		default:
+			attributes = append(attributes, field)
+		}
+	}
+	if event.GoroutineId != "" {
+		attributes = append(attributes, String("thread.name", event.GoroutineId))
+	}
+	if event.Err != nil {
+		attributes = append(attributes, String("exception.message", event.Err.Error()))
+	}
+	if event.Caller.Defined() {
+		attributes = append(attributes,
+			String("code.filepath", event.Caller.File),
+			Int("code.lineno", event.Caller.Line),
+			String("code.function", event.Caller.Function))
+	}
+	sb.WriteString(`,"attributes":`)
+	writeOTLPAttributes(sb, attributes)
+	if traceID != "" {
+		sb.WriteString(`,"traceId":`)
+		writeJSONString(sb, traceID)
+	}
+	if spanID != "" {
+		sb.WriteString(`,"spanId":`)
+		writeJSONString(sb, spanID)
+	}
+	sb.WriteByte('}')
+}
+
+func writeOTLPAttributes(sb *buffer, fields []Field) {
+	sb.WriteByte('[')
+	for i, field := range fields {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(`{"key":`)
+		writeJSONString(sb, field.Key)
+		sb.WriteString(`,"value":`)
+		writeOTLPValue(sb, field)
+		sb.WriteByte('}')
+	}
+	sb.WriteByte(']')
+}
+
+// writeOTLPValue writes field as AnyValue. 64 bit integers are strings in the JSON mapping of protobuf.
+// Unsigned values beyond the int64 range of intValue are written as stringValue.
+func writeOTLPValue(sb *buffer, field Field) {
+	switch field.Type {
+	case IntType, DurationType:
+		sb.WriteString(`{"intValue":"`)
+		sb.WriteString(strconv.FormatInt(field.Integer, 10))
+		sb.WriteString(`"}`)
+	case UintType:
+		if field.Integer < 0 {
+			sb.WriteString(`{"stringValue":"`)
+		} else {
+			sb.WriteString(`{"intValue":"`)
+		}
+		sb.WriteString(strconv.FormatUint(uint64(field.Integer), 10))
+		sb.WriteString(`"}`)
+	case FloatType:
+		f := math.Float64frombits(uint64(field.Integer))
+		sb.WriteString(`{"doubleValue":`)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			field.writeJSON(sb)
+		} else {
+			sb.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+		sb.WriteByte('}')
+	case BoolType:
+		sb.WriteString(`{"boolValue":`)
+		sb.WriteString(strconv.FormatBool(field.Integer != 0))
+		sb.WriteByte('}')
+	case ObjectType:
+		fields, _ := field.Interface.([]Field)
+		sb.WriteString(`{"kvlistValue":{"values":`)
+		writeOTLPAttributes(sb, fields)
+		sb.WriteString(`}}`)
+	case ArrayType:
+		fields, _ := field.Interface.([]Field)
+		sb.WriteString(`{"arrayValue":{"values":[`)
+		for i, f := range fields {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeOTLPValue(sb, f)
+		}
+		sb.WriteString(`]}}`)
+	default:
+		sb.WriteString(`{"stringValue":`)
+		writeJSONString(sb, field.text())
+		sb.WriteByte('}')
+	}
+}
+
+func (sink *OTLPSink) Flush() error { return sink.batcher.flush() }
+func (sink *OTLPSink) Close() error { return sink.batcher.close() }
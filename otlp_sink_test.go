@@ -0,0 +1,52 @@
+package go_logger_test
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestOTLPSinkValues(t *testing.T) {
+	var mutex sync.Mutex
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		body += string(data)
+		mutex.Unlock()
+	}))
+	defer server.Close()
+	sink := golog.NewOTLPSink(server.URL, 10, 0)
+	logger := golog.NewLogger("otlp").Sinks(sink).Level(golog.INFO)
+	logger.InfoEvent().
+		Int("int", -1).
+		Uint64("uint", 42).
+		Uint64("max", math.MaxUint64).
+		Str(golog.TraceIDKey, "4bf92f3577b34da6a3ce929d0e0e4736").
+		Msg("values")
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"negative int", `{"key":"int","value":{"intValue":"-1"}}`},
+		{"uint", `{"key":"uint","value":{"intValue":"42"}}`},
+		{"uint beyond int64", `{"key":"max","value":{"stringValue":"18446744073709551615"}}`},
+		{"trace id", `"traceId":"4bf92f3577b34da6a3ce929d0e0e4736"`},
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	for _, tt := range tests {
+		if !strings.Contains(body, tt.want) {
+			t.Errorf("%s: %s not in %s", tt.name, tt.want, body)
+		}
+	}
+}
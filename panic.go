@@ -0,0 +1,42 @@
+package go_logger
+
+import "fmt"
+
+// OnPanic sets the level Recover and LogPanic log at, ERROR by default, and whether they panic again
+// with the recovered value after logging.
+func (logger *Logger) OnPanic(level Level, repanic bool) *Logger {
+	logger = logger.derive()
+	logger.panicLevel = level
+	logger.repanic = repanic
+	return logger
+}
+
+// Recover logs a panic of the calling goroutine as described at LogPanic. It must be deferred directly:
+//
+//	defer logger.Recover()
+func (logger *Logger) Recover() {
+	if recovered := recover(); recovered != nil {
+		logger.LogPanic(recovered)
+	}
+}
+
+// LogPanic logs a value returned by recover together with the stack trace of the panic. A nil value is
+// ignored.
+func (logger *Logger) LogPanic(recovered any) {
+	if recovered == nil {
+		return
+	}
+	event := createEvent(logger.panicLevel, "panic", panicError(recovered))
+	event.Stack = captureStack()
+	logger.log(event)
+	if logger.repanic {
+		panic(recovered)
+	}
+}
+
+func panicError(recovered any) error {
+	if err, ok := recovered.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", recovered)
+}
@@ -0,0 +1,66 @@
+package go_logger_test
+
+import (
+	"errors"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestRecover(t *testing.T) {
+	boom := errors.New("boom")
+	tests := []struct {
+		name      string
+		level     golog.Level
+		repanic   bool
+		value     any
+		wantLevel golog.Level
+		wantErr   string
+	}{
+		{name: "error value", value: boom, wantLevel: golog.ERROR, wantErr: "boom"},
+		{name: "string value", value: "index out of range", wantLevel: golog.ERROR, wantErr: "index out of range"},
+		{name: "custom level", level: golog.FATAL, value: 42, wantLevel: golog.FATAL, wantErr: "42"},
+		{name: "repanic", repanic: true, value: boom, wantLevel: golog.ERROR, wantErr: "boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, observer := logtest.NewObservedLogger(golog.INFO)
+			if tt.level != 0 || tt.repanic {
+				level := tt.level
+				if level == 0 {
+					level = golog.ERROR
+				}
+				logger = logger.OnPanic(level, tt.repanic)
+			}
+			var repanicked any
+			func() {
+				defer func() { repanicked = recover() }()
+				defer logger.Recover()
+				panic(tt.value)
+			}()
+			if (repanicked != nil) != tt.repanic {
+				t.Errorf("panicked again with %v, want %v", repanicked, tt.repanic)
+			}
+			events := observer.All()
+			if len(events) != 1 {
+				t.Fatalf("%d events, want 1", len(events))
+			}
+			event := events[0]
+			if event.Level != tt.wantLevel || event.Message != "panic" || event.Err == nil || event.Err.Error() != tt.wantErr {
+				t.Errorf("logged %v %q %v, want %v panic %s", event.Level, event.Message, event.Err, tt.wantLevel, tt.wantErr)
+			}
+			if event.Stack == nil {
+				t.Error("no stack trace")
+			}
+		})
+	}
+}
+
+func TestLogPanicNil(t *testing.T) {
+	logger, observer := logtest.NewObservedLogger(golog.INFO)
+	logger.LogPanic(nil)
+	if observer.Len() != 0 {
+		t.Errorf("logged %v for a nil value", observer.All())
+	}
+}
@@ -0,0 +1,215 @@
+package go_logger
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// PatternEncoder renders events according to a log4j style pattern, e.g. "%t %-5l [%n] (%g) %m %e".
+// Conversions:
+//
+//	%t  timestamp, optionally with a Go layout: %t{15:04:05.000}
+//	%l  level name (INFO), %L level letter (I)
+//	%n  logger name
+//	%g  goroutine
+//	%m  message
+//	%e  error text
+//	%f  fields as key=value
+//	%c  caller as dir/file.go:line
+//	%S  stack trace, one frame per line
+//	%%  a percent sign
+//
+// A conversion may be padded to a minimum width, right aligned by default and left aligned with "-",
+// and truncated to a maximum length: %-10.10n. Trailing spaces are removed, so that empty conversions
+// at the end leave no gap.
+type PatternEncoder struct {
+	parts        []patternPart
+	colors       cls
+	TimeLayout   string
+	TimeLocation *time.Location
+}
+
+type patternPart struct {
+	literal string
+	verb    byte
+	left    bool
+	width   int
+	max     int
+	option  string
+}
+
+func NewPatternEncoder(pattern string) (*PatternEncoder, error) {
+	parts, err := parsePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &PatternEncoder{parts: parts, colors: clsOff}, nil
+}
+
+// MustPatternEncoder is NewPatternEncoder panicking on an invalid pattern.
+func MustPatternEncoder(pattern string) *PatternEncoder {
+	encoder, err := NewPatternEncoder(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return encoder
+}
+
+// Colorized colors timestamp, level, logger, goroutine and message like the plain format.
+func (encoder *PatternEncoder) Colorized(colorized bool) *PatternEncoder {
+	if colorized {
+		encoder.colors = clsOn
+	} else {
+		encoder.colors = clsOff
+	}
+	return encoder
+}
+
+func parsePattern(pattern string) ([]patternPart, error) {
+	var parts []patternPart
+	literal := buffer{}
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			literal.WriteByte(pattern[i])
+			continue
+		}
+		i++
+		if i < len(pattern) && pattern[i] == '%' {
+			literal.WriteByte('%')
+			continue
+		}
+		if literal.Len() > 0 {
+			parts = append(parts, patternPart{literal: literal.String()})
+			literal.Reset()
+		}
+		part := patternPart{}
+		if i < len(pattern) && pattern[i] == '-' {
+			part.left = true
+			i++
+		}
+		start := i
+		for i < len(pattern) && pattern[i] >= '0' && pattern[i] <= '9' {
+			i++
+		}
+		part.width, _ = strconv.Atoi(pattern[start:i])
+		if i < len(pattern) && pattern[i] == '.' {
+			i++
+			start = i
+			for i < len(pattern) && pattern[i] >= '0' && pattern[i] <= '9' {
+				i++
+			}
+			part.max, _ = strconv.Atoi(pattern[start:i])
+		}
+		if i >= len(pattern) {
+			return nil, errors.New("go_logger: pattern ends with incomplete conversion")
+		}
+		part.verb = pattern[i]
+		if !strings.ContainsRune("tlLngmefcS", rune(part.verb)) {
+			return nil, errors.New("go_logger: unknown pattern conversion %" + string(part.verb))
+		}
+		if i+1 < len(pattern) && pattern[i+1] == '{' {
+			end := strings.IndexByte(pattern[i+1:], '}')
+			if end < 0 {
+				return nil, errors.New("go_logger: unterminated option in pattern")
+			}
+			part.option = pattern[i+2 : i+1+end]
+			i += end + 1
+		}
+		parts = append(parts, part)
+	}
+	if literal.Len() > 0 {
+		parts = append(parts, patternPart{literal: literal.String()})
+	}
+	return parts, nil
+}
+
+func (encoder *PatternEncoder) Encode(sb *strings.Builder, event *Event) {
+	encodeTo(sb, encoder, event)
+}
+
+func (encoder *PatternEncoder) encode(sb *buffer, event *Event) {
+	line := buffer{}
+	value := buffer{}
+	for _, part := range encoder.parts {
+		if part.verb == 0 {
+			line.WriteString(part.literal)
+			continue
+		}
+		value.Reset()
+		color := encoder.writeConversion(&value, part, event)
+		line.WriteString(color)
+		writePadded(&line, value.String(), part)
+		if color != "" {
+			line.WriteString(encoder.colors.End.String())
+		}
+	}
+	sb.WriteString(strings.TrimRight(line.String(), " "))
+	sb.WriteByte('\n')
+}
+
+// writeConversion writes the value of a conversion and returns its color.
+func (encoder *PatternEncoder) writeConversion(sb *buffer, part patternPart, event *Event) string {
+	switch part.verb {
+	case 't':
+		layout := encoder.TimeLayout
+		if part.option != "" {
+			layout = part.option
+		}
+		var buf [64]byte
+		sb.Write(appendTimestamp(buf[:0], event.Timestamp, layout, encoder.TimeLocation, time.Time{}))
+		return encoder.colors.Timestamp.String()
+	case 'l':
+		sb.WriteString(event.Level.Long())
+		return levelColored(encoder.colors, event.Level)
+	case 'L':
+		sb.WriteString(event.Level.Short())
+		return levelColored(encoder.colors, event.Level)
+	case 'n':
+		sb.WriteString(event.Logger)
+		return encoder.colors.Logger.String()
+	case 'g':
+		sb.WriteString(event.GoroutineId)
+		return encoder.colors.GoRoutine.String()
+	case 'm':
+		sb.WriteString(event.Message)
+		return messageColored(encoder.colors, event.Level)
+	case 'e':
+		if event.Err != nil {
+			sb.WriteString(event.Err.Error())
+		}
+	case 'f':
+		for i, field := range event.Fields {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(field.Key)
+			sb.WriteByte('=')
+			field.writeText(sb)
+		}
+	case 'c':
+		if event.Caller.Defined() {
+			sb.WriteString(event.Caller.String())
+		}
+	case 'S':
+		writeStackFrames(sb, event.Stack, "\t")
+	}
+	return ""
+}
+
+func writePadded(sb *buffer, value string, part patternPart) {
+	if part.max > 0 && utf8.RuneCountInString(value) > part.max {
+		runes := []rune(value)
+		value = string(runes[:part.max])
+	}
+	padding := part.width - utf8.RuneCountInString(value)
+	if padding > 0 && !part.left {
+		sb.WriteString(strings.Repeat(" ", padding))
+	}
+	sb.WriteString(value)
+	if padding > 0 && part.left {
+		sb.WriteString(strings.Repeat(" ", padding))
+	}
+}
@@ -0,0 +1,60 @@
+package go_logger_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestPatternEncoder(t *testing.T) {
+	event := &golog.Event{
+		Timestamp:   time.Date(2024, 1, 2, 3, 4, 5, 678000000, time.UTC),
+		Logger:      "app.http",
+		GoroutineId: "17",
+		Level:       golog.WARN,
+		Message:     "slow request",
+		Err:         errors.New("timeout"),
+		Fields:      []golog.Field{golog.String("path", "/users"), golog.Int("status", 504)},
+		Caller:      golog.Caller{File: "/src/app/server/handler.go", Line: 42, Function: "app/server.Handle"},
+	}
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"%m", "slow request"},
+		{"%t{15:04:05.000} %l %L", "03:04:05.678 WARN W"},
+		{"[%n] (%g) %m: %e", "[app.http] (17) slow request: timeout"},
+		{"%m %f", "slow request path=/users status=504"},
+		{"%c", "server/handler.go:42"},
+		{"[%-6l]", "[WARN  ]"},
+		{"[%6l]", "[  WARN]"},
+		{"[%.3n]", "[app]"},
+		{"%-10.10n|", "app.http  |"},
+		{"100%% %m", "100% slow request"},
+		{"%m %S", "slow request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			encoder, err := golog.NewPatternEncoder(tt.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sb := strings.Builder{}
+			encoder.Encode(&sb, event)
+			if got := sb.String(); got != tt.want+"\n" {
+				t.Errorf("got %q, want %q", got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestPatternEncoderInvalid(t *testing.T) {
+	for _, pattern := range []string{"%", "%x", "%t{15:04"} {
+		if _, err := golog.NewPatternEncoder(pattern); err == nil {
+			t.Errorf("pattern %q accepted", pattern)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package go_logger
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jeschu/go-logger/colors"
+)
+
+// PrettyEncoder is a development encoder with aligned columns, level badges and every field, the
+// error and the stack trace on lines of their own. It is used for the PRETTY format.
+type PrettyEncoder struct {
+	colors cls
+	// Icons prefixes each line with a marker for its level.
+	Icons bool
+	// ErrorChain writes every cause of the error on a line of its own.
+	ErrorChain   bool
+	NameWidth    int
+	TimeLayout   string
+	TimeLocation *time.Location
+	TimeOrigin   time.Time
+}
+
+func NewPrettyEncoder(colorized bool) *PrettyEncoder {
+	encoder := &PrettyEncoder{colors: clsOff, NameWidth: 12, TimeLayout: "15:04:05.000"}
+	if colorized {
+		encoder.colors = clsOn
+	}
+	return encoder
+}
+
+const prettyIndent = "    "
+
+func (encoder *PrettyEncoder) Encode(sb *strings.Builder, event *Event) {
+	encodeTo(sb, encoder, event)
+}
+
+func (encoder *PrettyEncoder) encode(sb *buffer, event *Event) {
+	colorized := encoder.colors.End != ""
+	if encoder.Icons {
+		sb.WriteString(levelIcon(event.Level))
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(encoder.colors.Timestamp.String())
+	var buf [64]byte
+	sb.Write(appendTimestamp(buf[:0], event.Timestamp, encoder.TimeLayout, encoder.TimeLocation, encoder.TimeOrigin))
+	sb.WriteString(encoder.colors.End.String())
+	sb.WriteByte(' ')
+	if colorized {
+		sb.WriteString(levelBadge(event.Level).String())
+		sb.WriteByte(' ')
+		sb.WriteString(stringToLength(event.Level.Long(), 5))
+		sb.WriteByte(' ')
+		sb.WriteString(colors.END.String())
+	} else {
+		sb.WriteString(stringToLength(event.Level.Long(), 5))
+	}
+	sb.WriteByte(' ')
+	sb.WriteString(encoder.colors.Logger.String())
+	name := event.Logger
+	if encoder.NameWidth > 0 {
+		name = stringToLength(name, encoder.NameWidth)
+	}
+	sb.WriteString(name)
+	sb.WriteString(encoder.colors.End.String())
+	sb.WriteByte(' ')
+	if event.Caller.Defined() {
+		sb.WriteString(encoder.colors.Default.String())
+		sb.WriteString(event.Caller.String())
+		sb.WriteString(encoder.colors.End.String())
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(messageColored(encoder.colors, event.Level))
+	if colorized {
+		sb.WriteString(colors.BOLD.String())
+	}
+	sb.WriteString(event.Message)
+	sb.WriteString(encoder.colors.End.String())
+	if event.Err != nil {
+		sb.WriteByte('\n')
+		sb.WriteString(prettyIndent)
+		sb.WriteString(encoder.colors.Error.String())
+		sb.WriteString("error: ")
+		writeIndented(sb, event.Err.Error(), prettyIndent+"       ")
+		sb.WriteString(encoder.colors.End.String())
+		if encoder.ErrorChain {
+			for _, cause := range errorCauses(event.Err) {
+				sb.WriteByte('\n')
+				sb.WriteString(prettyIndent + "  caused by: ")
+				sb.WriteString(errorType(cause))
+				sb.WriteString(": ")
+				writeIndented(sb, cause.Error(), prettyIndent+"    ")
+			}
+		}
+	}
+	encoder.writeFields(sb, event.Fields, prettyIndent)
+	if event.Err != nil {
+		encoder.writeStack(sb, "error stack:", errorStack(event.Err))
+	}
+	encoder.writeStack(sb, "stack:", event.Stack)
+	sb.WriteByte('\n')
+}
+
+func (encoder *PrettyEncoder) writeStack(sb *buffer, title string, stack []Caller) {
+	if len(stack) == 0 {
+		return
+	}
+	sb.WriteByte('\n')
+	sb.WriteString(prettyIndent)
+	sb.WriteString(title)
+	sb.WriteString(encoder.colors.Default.String())
+	writeStackFrames(sb, stack, prettyIndent+"  ")
+	sb.WriteString(encoder.colors.End.String())
+}
+
+// writeFields writes one field per line with aligned keys; objects are expanded recursively.
+func (encoder *PrettyEncoder) writeFields(sb *buffer, fields []Field, indent string) {
+	width := 0
+	for _, field := range fields {
+		if len(field.Key) > width {
+			width = len(field.Key)
+		}
+	}
+	for _, field := range fields {
+		sb.WriteByte('\n')
+		sb.WriteString(indent)
+		sb.WriteString(encoder.colors.Logger.String())
+		sb.WriteString(field.Key)
+		sb.WriteString(encoder.colors.End.String())
+		if field.Type == ObjectType {
+			sb.WriteByte(':')
+			nested, _ := field.Interface.([]Field)
+			encoder.writeFields(sb, nested, indent+"  ")
+			continue
+		}
+		sb.WriteString(strings.Repeat(" ", width-len(field.Key)))
+		sb.WriteString(" = ")
+		writeIndented(sb, field.text(), indent+strings.Repeat(" ", width+3))
+	}
+}
+
+// writeIndented writes text, indenting all lines but the first.
+func writeIndented(sb *buffer, text, indent string) {
+	sb.WriteString(strings.ReplaceAll(strings.TrimRight(text, "\n"), "\n", "\n"+indent))
+}
+
+func levelBadge(level Level) colors.Color {
+	switch level {
+	case TRACE:
+		return colors.GREYBG + colors.WHITE2
+	case DEBUG:
+		return colors.BLUEBG + colors.WHITE2
+	case INFO:
+		return colors.GREENBG + colors.BLACK
+	case WARN:
+		return colors.YELLOWBG + colors.BLACK
+	case ERROR:
+		return colors.REDBG + colors.WHITE2
+	case FATAL:
+		return colors.REDBG2 + colors.WHITE2 + colors.BOLD
+	default:
+		return colors.GREYBG
+	}
+}
+
+func levelIcon(level Level) string {
+	switch level {
+	case TRACE:
+		return "🔍"
+	case DEBUG:
+		return "🐛"
+	case INFO:
+		return "💡"
+	case WARN:
+		return "⚠️"
+	case ERROR:
+		return "❌"
+	case FATAL:
+		return "💀"
+	default:
+		return "  "
+	}
+}
+
+// NewDevelopment returns a logger for local development: PRETTY format with icons, level DEBUG,
+// callers, and stack traces from WARN on.
+func NewDevelopment(name string) *Logger {
+	return NewLogger(name).Out(os.Stderr).Format(PRETTY).Icons(true).Level(DEBUG).WithCaller(true).StackTraceAt(WARN)
+}
+
+// NewProduction returns a logger for production: compact JSON, level INFO and stack traces from
+// ERROR on.
+func NewProduction(name string) *Logger {
+	return NewLogger(name).Format(JSON).Level(INFO).StackTraceAt(ERROR)
+}
@@ -0,0 +1,80 @@
+package go_logger
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// Progress logs the progress of a long operation at INFO, at most once per period: the count done so
+// far, the rate per second and, if the total is known, percent and estimated time remaining. It is
+// safe for concurrent use.
+type Progress struct {
+	logger    *Logger
+	operation string
+	total     int64
+	period    atomic.Int64
+	start     time.Time
+	count     atomic.Int64
+	next      atomic.Int64
+	finished  atomic.Bool
+}
+
+// Progress returns a progress of operation over total units of work; a total of 0 or less means unknown.
+// Lines are logged every 10 seconds unless changed with Every.
+func (logger *Logger) Progress(operation string, total int64) *Progress {
+	progress := &Progress{logger: logger, operation: operation, total: total, start: logger.now()}
+	progress.Every(10 * time.Second)
+	return progress
+}
+
+// Every sets the minimum period between two progress lines.
+func (progress *Progress) Every(period time.Duration) *Progress {
+	progress.period.Store(int64(period))
+	progress.next.Store(progress.start.UnixNano() + int64(period))
+	return progress
+}
+
+// Add adds n units of work done and logs a progress line if the period has passed.
+func (progress *Progress) Add(n int64) {
+	count := progress.count.Add(n)
+	if progress.finished.Load() || !progress.logger.enabled(INFO) {
+		return
+	}
+	now := progress.logger.now()
+	next := progress.next.Load()
+	if now.UnixNano() < next || !progress.next.CompareAndSwap(next, now.UnixNano()+progress.period.Load()) {
+		return
+	}
+	progress.log(progress.operation+" in progress", count, now)
+}
+
+// Done logs the final line with the count and the total duration. Later calls do nothing.
+func (progress *Progress) Done() {
+	if progress.finished.Swap(true) || !progress.logger.enabled(INFO) {
+		return
+	}
+	progress.log(progress.operation+" finished", progress.count.Load(), progress.logger.now())
+}
+
+func (progress *Progress) log(msg string, count int64, now time.Time) {
+	logger := progress.logger
+	elapsed := now.Sub(progress.start)
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(count) / elapsed.Seconds()
+	}
+	fields := append(logger.fields[:len(logger.fields):len(logger.fields)],
+		String("operation", progress.operation), Int64("done", count))
+	if progress.total > 0 {
+		fields = append(fields, Int64("total", progress.total),
+			Float64("percent", math.Round(float64(count)*1000/float64(progress.total))/10))
+	}
+	fields = append(fields, Float64("rate", math.Round(rate*100)/100), Duration("elapsed", elapsed.Round(time.Millisecond)))
+	if remaining := progress.total - count; remaining > 0 && rate > 0 && !progress.finished.Load() {
+		fields = append(fields, Duration("eta", time.Duration(float64(remaining)/rate*float64(time.Second)).Round(time.Second)))
+	}
+	event := createEvent(INFO, msg, nil)
+	event.Fields = fields
+	logger.log(event)
+}
@@ -0,0 +1,99 @@
+package go_logger
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"strings"
+)
+
+const (
+	RequestIDHeader   = "X-Request-ID"
+	TraceparentHeader = "traceparent"
+)
+
+// ParseTraceparent parses a W3C traceparent header value such as
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func ParseTraceparent(value string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return SpanContext{}, false
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, false
+	}
+	for _, part := range parts[:4] {
+		if !isLowerHex(part) {
+			return SpanContext{}, false
+		}
+	}
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return SpanContext{}, false
+	}
+	return SpanContext{TraceID: parts[1], SpanID: parts[2]}, true
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !(s[i] >= '0' && s[i] <= '9' || s[i] >= 'a' && s[i] <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Traceparent formats span as sampled W3C traceparent header value.
+func (span SpanContext) Traceparent() string {
+	return "00-" + span.TraceID + "-" + span.SpanID + "-01"
+}
+
+// NewSpanContext returns a span with random trace and span IDs.
+func NewSpanContext() SpanContext {
+	return SpanContext{TraceID: randomHex(16), SpanID: randomHex(8)}
+}
+
+// NewRequestID returns a random ID of 32 hex digits.
+func NewRequestID() string {
+	return randomHex(16)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	out := make([]byte, 2*n)
+	for i, c := range b {
+		out[2*i] = hex[c>>4]
+		out[2*i+1] = hex[c&0xf]
+	}
+	return string(out)
+}
+
+// RequestID returns the X-Request-ID header of r, or a new ID if it is absent.
+func RequestID(r *http.Request) string {
+	if id := strings.TrimSpace(r.Header.Get(RequestIDHeader)); id != "" {
+		return id
+	}
+	return NewRequestID()
+}
+
+// SpanFromRequest returns the span of the traceparent header of r, or a new span if it is absent or invalid.
+func SpanFromRequest(r *http.Request) SpanContext {
+	if span, ok := ParseTraceparent(r.Header.Get(TraceparentHeader)); ok {
+		return span
+	}
+	return NewSpanContext()
+}
+
+// ContextFromRequest returns the context of r carrying its request ID and span, so that FromContext and
+// the Ctx logging methods add the fields request_id, trace_id and span_id. Missing IDs are generated.
+// A span already active in the request context, e.g. started by tracing middleware, is kept.
+func ContextFromRequest(r *http.Request) context.Context {
+	ctx := r.Context()
+	if RequestIDFromContext(ctx) == "" {
+		ctx = ContextWithRequestID(ctx, RequestID(r))
+	}
+	if _, ok := SpanFromContext(ctx); !ok {
+		ctx = ContextWithSpan(ctx, SpanFromRequest(r))
+	}
+	return ctx
+}
@@ -0,0 +1,80 @@
+package go_logger
+
+import (
+	"context"
+	"time"
+)
+
+// QueryLogger logs database queries with duration and affected rows. Failed queries are logged at
+// ERROR, queries slower than the threshold at WARN and all others at the configured level, DEBUG by
+// default. Query arguments are only logged if enabled, as they may contain personal data.
+type QueryLogger struct {
+	logger        *Logger
+	level         Level
+	slowThreshold time.Duration
+	logArgs       bool
+}
+
+func NewQueryLogger(logger *Logger) *QueryLogger {
+	return &QueryLogger{logger: logger, level: DEBUG, slowThreshold: 200 * time.Millisecond}
+}
+
+func (queryLogger *QueryLogger) Level(level Level) *QueryLogger {
+	queryLogger.level = level
+	return queryLogger
+}
+
+// SlowThreshold sets the duration above which queries are logged as slow. Zero disables slow query warnings.
+func (queryLogger *QueryLogger) SlowThreshold(threshold time.Duration) *QueryLogger {
+	queryLogger.slowThreshold = threshold
+	return queryLogger
+}
+
+func (queryLogger *QueryLogger) LogArgs(logArgs bool) *QueryLogger {
+	queryLogger.logArgs = logArgs
+	return queryLogger
+}
+
+// Slow reports whether a query taking duration exceeds the slow query threshold.
+func (queryLogger *QueryLogger) Slow(duration time.Duration) bool {
+	return queryLogger.slowThreshold > 0 && duration > queryLogger.slowThreshold
+}
+
+// Logger returns the logger queries are written to.
+func (queryLogger *QueryLogger) Logger() *Logger {
+	return queryLogger.logger
+}
+
+// Log logs a finished query. A negative rows is omitted.
+func (queryLogger *QueryLogger) Log(ctx context.Context, query string, duration time.Duration, rows int64, err error, args ...any) {
+	level, msg := queryLogger.level, "query"
+	switch {
+	case err != nil:
+		level, msg = ERROR, "query failed"
+	case queryLogger.Slow(duration):
+		level, msg = WARN, "slow query"
+	}
+	logger := queryLogger.logger
+	if !logger.enabled(level) {
+		return
+	}
+	event := createEvent(level, msg, err)
+	fields := append(logger.fields[:len(logger.fields):len(logger.fields)], contextFields(ctx)...)
+	fields = append(fields, String("query", query), Duration("duration", duration))
+	if rows >= 0 {
+		fields = append(fields, Int64("rows", rows))
+	}
+	if queryLogger.logArgs && len(args) > 0 {
+		fields = append(fields, Any("args", args))
+	}
+	event.Fields = fields
+	logger.log(event)
+}
+
+// Measure runs exec, which returns the affected rows, and logs query with its duration.
+func (queryLogger *QueryLogger) Measure(ctx context.Context, query string, exec func() (int64, error), args ...any) error {
+	start := time.Now()
+	rows, err := exec()
+	queryLogger.Log(ctx, query, time.Since(start), rows, err, args...)
+	return err
+}
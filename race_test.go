@@ -0,0 +1,8 @@
+//go:build race
+
+package go_logger_test
+
+func init() {
+	// sync.Pool drops items at random under the race detector
+	raceEnabled = true
+}
@@ -0,0 +1,84 @@
+package go_logger
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket holding up to burst tokens, refilled at rate tokens per second.
+type rateLimiter struct {
+	mutex      sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	last       time.Time
+	suppressed int
+}
+
+// RateLimit limits the logger and all children derived from it to events per duration,
+// allowing bursts of up to events. Excess events are dropped; the next event passing the limit is
+// preceded by a WARN event with the number of suppressed lines. FATAL events are never dropped.
+// A non-positive events disables the limit.
+func (logger *Logger) RateLimit(events int, per time.Duration) *Logger {
+	logger = logger.derive()
+	if events <= 0 || per <= 0 {
+		logger.rateLimiter = nil
+		return logger
+	}
+	logger.rateLimiter = &rateLimiter{
+		rate:   float64(events) / per.Seconds(),
+		burst:  float64(events),
+		tokens: float64(events),
+		last:   time.Now(),
+	}
+	return logger
+}
+
+// allow takes a token and returns whether the event may pass, plus the number of events suppressed
+// since the last event passed. A forced event passes without a token and is not counted as suppressed.
+func (limiter *rateLimiter) allow(now time.Time, force bool) (bool, int) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	// a clock behind the limiter's start, or going backwards, must not take tokens
+	if elapsed := now.Sub(limiter.last); elapsed > 0 {
+		limiter.tokens = min(limiter.tokens+elapsed.Seconds()*limiter.rate, limiter.burst)
+	}
+	limiter.last = now
+	if limiter.tokens >= 1 {
+		limiter.tokens--
+	} else if !force {
+		limiter.suppressed++
+		return false, 0
+	}
+	suppressed := limiter.suppressed
+	limiter.suppressed = 0
+	return true, suppressed
+}
+
+// rateLimited reports whether event is dropped by the rate limit and writes the suppression notice.
+func (logger *Logger) rateLimited(event *Event) bool {
+	if logger.rateLimiter == nil {
+		return false
+	}
+	allowed, suppressed := logger.rateLimiter.allow(event.Timestamp, event.Level == FATAL)
+	if !allowed {
+		countDropped(dropRateLimited)
+		logger.stats.dropped.Add(1)
+		return true
+	}
+	if suppressed > 0 {
+		notice := createEvent(WARN, "rate limit suppressed "+strconv.Itoa(suppressed)+" log lines", nil)
+		notice.Timestamp = event.Timestamp
+		notice.Logger = event.Logger
+		notice.GoroutineId = event.GoroutineId
+		notice.Fields = []Field{Int("suppressed", suppressed)}
+		logger.runPreEncodeHooks(notice)
+		logger.sanitize(notice)
+		if logger.write(notice) {
+			logger.runPostWriteHooks(notice)
+		}
+		releaseEvent(notice)
+	}
+	return false
+}
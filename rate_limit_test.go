@@ -0,0 +1,82 @@
+package go_logger_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestRateLimit(t *testing.T) {
+	// steps are logged at INFO, F at FATAL; + advances the clock by one second, refilling one token
+	tests := []struct {
+		name  string
+		steps string
+		want  []string
+	}{
+		{name: "within burst", steps: "a b", want: []string{"a", "b"}},
+		{name: "over burst", steps: "a b c d", want: []string{"a", "b"}},
+		{
+			name:  "notice after refill",
+			steps: "a b c d + e",
+			want:  []string{"a", "b", "rate limit suppressed 2 log lines", "e"},
+		},
+		{name: "fatal passes uncounted", steps: "a b F + e", want: []string{"a", "b", "F", "e"}},
+		{
+			name:  "fatal reports suppressed",
+			steps: "a b c F",
+			want:  []string{"a", "b", "rate limit suppressed 1 log lines", "F"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := golog.NewManualClock(time.Now())
+			logger, observer := logtest.NewObservedLogger(golog.INFO)
+			logger = logger.Clock(clock).RateLimit(2, 2*time.Second)
+			for _, step := range strings.Fields(tt.steps) {
+				switch step {
+				case "+":
+					clock.Advance(time.Second)
+				case "F":
+					logger.Fatal(step)
+				default:
+					logger.Info(step)
+				}
+			}
+			var got []string
+			for _, event := range observer.All() {
+				got = append(got, event.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitNoticeHooks(t *testing.T) {
+	clock := golog.NewManualClock(time.Now())
+	var preEncoded, written []string
+	logger, observer := logtest.NewObservedLogger(golog.INFO)
+	logger = logger.Clock(clock).RateLimit(1, time.Second).
+		PreEncode(func(event *golog.Event) { preEncoded = append(preEncoded, event.Message) }).
+		PostWrite(func(event *golog.Event) { written = append(written, event.Message) })
+	logger.Info("a")
+	logger.Info("b")
+	clock.Advance(time.Second)
+	logger.Info("c")
+	want := []string{"a", "rate limit suppressed 1 log lines", "c"}
+	if !slices.Equal(preEncoded, want) {
+		t.Errorf("pre-encode hooks ran for %q, want %q", preEncoded, want)
+	}
+	if !slices.Equal(written, want) {
+		t.Errorf("post-write hooks ran for %q, want %q", written, want)
+	}
+	notices := observer.FilterLevel(golog.WARN).All()
+	if len(notices) != 1 || len(notices[0].Fields) != 1 || notices[0].Fields[0].Key != "suppressed" {
+		t.Errorf("notices %v, want one with the field suppressed", notices)
+	}
+}
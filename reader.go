@@ -0,0 +1,272 @@
+package go_logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMalformedEvent is wrapped by the errors of EventReader.Read for lines that cannot be parsed.
+var ErrMalformedEvent = errors.New("go_logger: malformed event")
+
+// EventReader parses events from the JSON or PLAIN output of this package, one event per line. PLAIN
+// lines are parsed on a best effort basis: the fields are the trailing key=value pairs, with values
+// running up to the next pair, and the error stays part of the message. Stack trace lines are skipped
+// and colors are removed.
+type EventReader struct {
+	scanner *bufio.Scanner
+	format  Format
+	line    int
+	Keys    JSONKeys
+}
+
+func NewEventReader(r io.Reader, format Format) *EventReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxDecodeLength)
+	return &EventReader{scanner: scanner, format: format, Keys: DefaultJSONKeys}
+}
+
+// Read parses the next event. It returns io.EOF at the end of the input and an error wrapping
+// ErrMalformedEvent for a line that cannot be parsed; reading may continue after the latter.
+func (reader *EventReader) Read(event *Event) error {
+	if reader.format != JSON && reader.format != PLAIN {
+		return fmt.Errorf("go_logger: cannot read format %s", reader.format)
+	}
+	for reader.scanner.Scan() {
+		reader.line++
+		line := reader.scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 || line[0] == '\t' {
+			continue
+		}
+		var err error
+		if reader.format == JSON {
+			err = reader.readJSON(line, event)
+		} else {
+			err = readPlain(string(stripColors(line)), event)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: line %d: %v", ErrMalformedEvent, reader.line, err)
+		}
+		return nil
+	}
+	if err := reader.scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+// Text returns the line the last call to Read parsed or failed to parse.
+func (reader *EventReader) Text() string {
+	return reader.scanner.Text()
+}
+
+// ReadEvents returns an iterator over the events in r, see EventReader. Lines that cannot be parsed
+// are skipped; iteration ends at the end of the input or at a read error. The result can be ranged
+// over and converted to iter.Seq[Event].
+func ReadEvents(r io.Reader, format Format) func(yield func(Event) bool) {
+	return func(yield func(Event) bool) {
+		reader := NewEventReader(r, format)
+		for {
+			var event Event
+			err := reader.Read(&event)
+			if errors.Is(err, ErrMalformedEvent) {
+				continue
+			}
+			if err != nil || !yield(event) {
+				return
+			}
+		}
+	}
+}
+
+func (reader *EventReader) readJSON(line []byte, event *Event) error {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	value, err := readJSONValue(decoder, "", 0)
+	if err != nil {
+		return err
+	}
+	if value.Type != ObjectType {
+		return errDecodeNoMap
+	}
+	entries, _ := value.Interface.([]Field)
+	*event = eventFromFields(reader.Keys, entries)
+	return nil
+}
+
+// readJSONValue reads one value as a field with the given key, keeping the order of object members.
+func readJSONValue(decoder *json.Decoder, key string, depth int) (Field, error) {
+	if depth > maxDecodeDepth {
+		return Field{}, errDecodeDepth
+	}
+	token, err := decoder.Token()
+	if err != nil {
+		return Field{}, err
+	}
+	switch value := token.(type) {
+	case json.Delim:
+		var fields []Field
+		for decoder.More() {
+			name := ""
+			if value == '{' {
+				token, err := decoder.Token()
+				if err != nil {
+					return Field{}, err
+				}
+				name, _ = token.(string)
+			}
+			field, err := readJSONValue(decoder, name, depth+1)
+			if err != nil {
+				return Field{}, err
+			}
+			fields = append(fields, field)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return Field{}, err
+		}
+		if value == '{' {
+			return Object(key, fields...), nil
+		}
+		return Array(key, fields...), nil
+	case json.Number:
+		return numberField(key, string(value)), nil
+	case string:
+		return String(key, value), nil
+	case bool:
+		return Bool(key, value), nil
+	default:
+		return Field{Key: key, Type: AnyType}, nil
+	}
+}
+
+// numberField returns an int, uint or float field for a number and a string field for anything else.
+func numberField(key, text string) Field {
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return Int64(key, i)
+	}
+	if u, err := strconv.ParseUint(text, 10, 64); err == nil {
+		return Uint64(key, u)
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return Float64(key, f)
+	}
+	return String(key, text)
+}
+
+// readPlain parses "timestamp -L- [logger] (goroutine) caller message key=value ...".
+func readPlain(line string, event *Event) error {
+	*event = Event{}
+	timestamp, rest, ok := strings.Cut(line, " -")
+	if !ok || len(rest) < 2 || rest[1] != '-' {
+		return errors.New("no level")
+	}
+	event.Timestamp, _ = time.Parse(time.RFC3339Nano, timestamp)
+	level, err := ParseLevel(rest[:1])
+	if err != nil {
+		return err
+	}
+	event.Level = level
+	rest = strings.TrimPrefix(rest[2:], " ")
+	if strings.HasPrefix(rest, "[") {
+		name, after, ok := strings.Cut(rest[1:], "] ")
+		if !ok {
+			return errors.New("unterminated logger")
+		}
+		event.Logger, rest = strings.TrimRight(name, " "), after
+	}
+	if strings.HasPrefix(rest, "(") {
+		if goroutine, after, ok := strings.Cut(rest[1:], ") "); ok {
+			event.GoroutineId, rest = strings.TrimRight(goroutine, " "), after
+		}
+	}
+	if token, after, ok := strings.Cut(rest, " "); ok && strings.Contains(token, ".go:") {
+		if caller := parseCaller(token); caller.Line > 0 {
+			event.Caller, rest = caller, after
+		}
+	}
+	words := strings.Split(rest, " ")
+	start := len(words)
+	for i, word := range words {
+		if isPlainPair(word) {
+			start = i
+			break
+		}
+	}
+	event.Message = strings.Join(words[:start], " ")
+	nesting := 0
+	for _, word := range words[start:] {
+		if nesting <= 0 && isPlainPair(word) {
+			key, value, _ := strings.Cut(word, "=")
+			event.Fields = append(event.Fields, String(key, value))
+			nesting = plainNesting(value)
+			continue
+		}
+		last := &event.Fields[len(event.Fields)-1]
+		last.String += " " + word
+		nesting += plainNesting(word)
+	}
+	for i, field := range event.Fields {
+		event.Fields[i] = plainValue(field.Key, field.String)
+	}
+	return nil
+}
+
+// isPlainPair reports whether word starts with an identifier followed by '='.
+func isPlainPair(word string) bool {
+	key, _, ok := strings.Cut(word, "=")
+	if !ok || key == "" {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !(c == '_' || c == '.' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// plainNesting returns the number of objects and arrays opened minus those closed in text.
+func plainNesting(text string) int {
+	return strings.Count(text, "{") + strings.Count(text, "[") - strings.Count(text, "}") - strings.Count(text, "]")
+}
+
+// plainValue restores the type of numbers and booleans written as text.
+func plainValue(key, text string) Field {
+	if text == "true" || text == "false" {
+		return Bool(key, text == "true")
+	}
+	if text != "" && (text[0] == '-' || text[0] >= '0' && text[0] <= '9') {
+		if field := numberField(key, text); field.Type != StringType {
+			return field
+		}
+	}
+	return String(key, text)
+}
+
+// stripColors removes ANSI escape sequences.
+func stripColors(line []byte) []byte {
+	if bytes.IndexByte(line, 0x1b) < 0 {
+		return line
+	}
+	stripped := make([]byte, 0, len(line))
+	for i := 0; i < len(line); i++ {
+		if line[i] != 0x1b {
+			stripped = append(stripped, line[i])
+			continue
+		}
+		if i+1 < len(line) && line[i+1] == '[' {
+			i += 2
+			for i < len(line) && (line[i] < 0x40 || line[i] > 0x7e) {
+				i++
+			}
+		}
+	}
+	return stripped
+}
@@ -0,0 +1,172 @@
+package go_logger
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const redactedMask = "[REDACTED]"
+
+// Redactor masks secrets in the message (key is empty), the error text (key "error") and string
+// values of fields before an event is encoded.
+type Redactor interface {
+	Redact(key, value string) string
+}
+
+type RedactorFunc func(key, value string) string
+
+func (f RedactorFunc) Redact(key, value string) string { return f(key, value) }
+
+// RegexRedactor replaces all matches of the pattern, in messages as well as in field values.
+func RegexRedactor(pattern *regexp.Regexp, replacement string) Redactor {
+	return RedactorFunc(func(_, value string) string {
+		return pattern.ReplaceAllString(value, replacement)
+	})
+}
+
+// KeyRedactor masks the complete value of fields whose key contains one of the given words, case-insensitively.
+func KeyRedactor(words ...string) Redactor {
+	lower := make([]string, len(words))
+	for i, word := range words {
+		lower[i] = strings.ToLower(word)
+	}
+	return RedactorFunc(func(key, value string) string {
+		if key == "" {
+			return value
+		}
+		key = strings.ToLower(key)
+		for _, word := range lower {
+			if strings.Contains(key, word) {
+				return redactedMask
+			}
+		}
+		return value
+	})
+}
+
+var (
+	RedactSecretKeys = KeyRedactor("password", "passwd", "secret", "token", "apikey", "api_key", "authorization", "credential", "private_key")
+	RedactEmails     = RegexRedactor(regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), redactedMask)
+	RedactBearer     = RegexRedactor(regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`), "$1 "+redactedMask)
+	RedactPasswords  = RegexRedactor(regexp.MustCompile(`(?i)\b(password|passwd|pwd|secret|token)(\s*[=:]\s*)("[^"]*"|\S+)`), "$1$2"+redactedMask)
+	RedactCards      = RedactorFunc(redactCards)
+)
+
+// DefaultRedactors returns the built-in rule set: secret keys, passwords in messages, bearer tokens,
+// e-mail addresses and credit card numbers.
+func DefaultRedactors() []Redactor {
+	return []Redactor{RedactSecretKeys, RedactPasswords, RedactBearer, RedactEmails, RedactCards}
+}
+
+var cardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+
+// redactCards masks digit sequences of card number length passing the Luhn check.
+func redactCards(_, value string) string {
+	return cardPattern.ReplaceAllStringFunc(value, func(match string) string {
+		digits := strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, match)
+		if !luhn(digits) {
+			return match
+		}
+		return redactedMask
+	})
+}
+
+func luhn(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// Redact adds redactors to the logger, applied in order to every event before it is encoded.
+func (logger *Logger) Redact(redactors ...Redactor) *Logger {
+	logger = logger.derive()
+	logger.redactors = append(logger.redactors[:len(logger.redactors):len(logger.redactors)], redactors...)
+	return logger
+}
+
+func redactEvent(event *Event, redactors []Redactor) {
+	event.Message = redactValue("", event.Message, redactors)
+	if event.Err != nil {
+		text := event.Err.Error()
+		if redacted := redactValue("error", text, redactors); redacted != text {
+			event.Err = errors.New(redacted)
+		}
+	}
+	if fields, changed := redactFields(event.Fields, redactors); changed {
+		event.Fields = fields
+	}
+}
+
+// redactFields returns a redacted copy of fields if any value changed, the shared slice is never modified.
+func redactFields(fields []Field, redactors []Redactor) ([]Field, bool) {
+	var result []Field
+	for i, field := range fields {
+		redacted, changed := redactField(field, redactors)
+		if !changed {
+			continue
+		}
+		if result == nil {
+			result = make([]Field, len(fields))
+			copy(result, fields)
+		}
+		result[i] = redacted
+	}
+	return result, result != nil
+}
+
+func redactField(field Field, redactors []Redactor) (Field, bool) {
+	switch field.Type {
+	case StringType:
+		if value := redactValue(field.Key, field.String, redactors); value != field.String {
+			return String(field.Key, value), true
+		}
+	case ErrorType, AnyType:
+		if field.Interface == nil {
+			return field, false
+		}
+		text := field.text()
+		if value := redactValue(field.Key, text, redactors); value != text {
+			return String(field.Key, value), true
+		}
+	case ObjectType:
+		fields, _ := field.Interface.([]Field)
+		if redacted, changed := redactFields(fields, redactors); changed {
+			return Object(field.Key, redacted...), true
+		}
+	case ArrayType:
+		values, _ := field.Interface.([]Field)
+		if redacted, changed := redactFields(values, redactors); changed {
+			return Array(field.Key, redacted...), true
+		}
+	default:
+		text := field.text()
+		if value := redactValue(field.Key, text, redactors); value != text {
+			return String(field.Key, value), true
+		}
+	}
+	return field, false
+}
+
+func redactValue(key, value string, redactors []Redactor) string {
+	for _, redactor := range redactors {
+		value = redactor.Redact(key, value)
+	}
+	return value
+}
@@ -0,0 +1,110 @@
+package go_logger_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name        string
+		redactors   []golog.Redactor
+		message     string
+		err         error
+		fields      []golog.Field
+		wantMessage string
+		wantErr     string
+		wantFields  map[string]string
+	}{
+		{
+			name:        "secret keys",
+			redactors:   []golog.Redactor{golog.RedactSecretKeys},
+			message:     "login",
+			fields:      []golog.Field{golog.String("user", "alice"), golog.String("Password", "hunter2"), golog.Int("api_key", 42)},
+			wantMessage: "login",
+			wantFields:  map[string]string{"user": "alice", "Password": "[REDACTED]", "api_key": "[REDACTED]"},
+		},
+		{
+			name:        "password in message",
+			redactors:   []golog.Redactor{golog.RedactPasswords},
+			message:     `connect with password="s3 cret" and token: abc`,
+			wantMessage: `connect with password=[REDACTED] and token: [REDACTED]`,
+		},
+		{
+			name:        "bearer token in error",
+			redactors:   []golog.Redactor{golog.RedactBearer},
+			message:     "request failed",
+			err:         errors.New("401 for Authorization: Bearer eyJhbGciOi.x-y"),
+			wantMessage: "request failed",
+			wantErr:     "401 for Authorization: Bearer [REDACTED]",
+		},
+		{
+			name:        "emails",
+			redactors:   []golog.Redactor{golog.RedactEmails},
+			message:     "mail to bob.smith+x@example.co.uk failed",
+			fields:      []golog.Field{golog.Object("to", golog.String("address", "eve@example.org"))},
+			wantMessage: "mail to [REDACTED] failed",
+			wantFields:  map[string]string{"to.address": "[REDACTED]"},
+		},
+		{
+			name:        "card numbers",
+			redactors:   []golog.Redactor{golog.RedactCards},
+			message:     "paid with 4111 1111 1111 1111, order 1234567890123",
+			wantMessage: "paid with [REDACTED], order 1234567890123",
+		},
+		{
+			name:        "custom regex",
+			redactors:   []golog.Redactor{golog.RegexRedactor(regexp.MustCompile(`\d{3}-\d{2}-\d{4}`), "***")},
+			message:     "ssn 123-45-6789",
+			wantMessage: "ssn ***",
+		},
+		{
+			name:        "applied in order",
+			redactors:   []golog.Redactor{golog.RegexRedactor(regexp.MustCompile(`a`), "b"), golog.RegexRedactor(regexp.MustCompile(`b`), "c")},
+			message:     "a",
+			wantMessage: "c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, observer := logtest.NewObservedLogger(golog.INFO)
+			shared := append([]golog.Field(nil), tt.fields...)
+			logger.Redact(tt.redactors...).With(shared...).WithError(tt.err).Info(tt.message)
+			events := observer.All()
+			if len(events) != 1 {
+				t.Fatalf("%d events, want 1", len(events))
+			}
+			event := events[0]
+			if event.Message != tt.wantMessage {
+				t.Errorf("message %q, want %q", event.Message, tt.wantMessage)
+			}
+			if tt.wantErr != "" && (event.Err == nil || event.Err.Error() != tt.wantErr) {
+				t.Errorf("error %v, want %q", event.Err, tt.wantErr)
+			}
+			got := map[string]string{}
+			for _, field := range event.Fields {
+				if nested, ok := field.Interface.([]golog.Field); ok && field.Type == golog.ObjectType {
+					for _, inner := range nested {
+						got[field.Key+"."+inner.Key] = inner.String
+					}
+				} else {
+					got[field.Key] = field.String
+				}
+			}
+			for key, want := range tt.wantFields {
+				if got[key] != want {
+					t.Errorf("field %s is %q, want %q", key, got[key], want)
+				}
+			}
+			for i, field := range tt.fields {
+				if shared[i].Type != field.Type || shared[i].String != field.String {
+					t.Errorf("the logger's field %s was modified", field.Key)
+				}
+			}
+		})
+	}
+}
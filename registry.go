@@ -0,0 +1,109 @@
+package go_logger
+
+import (
+	"path"
+	"strings"
+	"sync"
+)
+
+// The registry hands out one logger per dot separated name. Levels are configured by name: a rule for
+// "http" applies to "http" and all of its descendants unless a more specific rule matches, "http.*" only
+// applies to the descendants and "*" to every logger. Other patterns are matched segment-wise with path.Match.
+var registry = struct {
+	mutex   sync.Mutex
+	loggers map[string]*Logger
+	rules   []levelRule
+}{loggers: make(map[string]*Logger)}
+
+type levelRule struct {
+	pattern     string
+	level       Level
+	specificity int
+}
+
+// GetLogger returns the registered logger for name, creating it from the default logger on first use.
+func GetLogger(name string) *Logger {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if logger, ok := registry.loggers[name]; ok {
+		return logger
+	}
+	logger := Default().Named(name)
+	if level, ok := effectiveLevel(name); ok {
+		logger.SetLevel(level)
+	}
+	registry.loggers[name] = logger
+	return logger
+}
+
+// SetLevel sets the level of all registered and future loggers matching pattern.
+func SetLevel(pattern string, level Level) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	rule := levelRule{pattern: pattern, level: level, specificity: patternSpecificity(pattern)}
+	replaced := false
+	for i := range registry.rules {
+		if registry.rules[i].pattern == pattern {
+			registry.rules[i] = rule
+			replaced = true
+		}
+	}
+	if !replaced {
+		registry.rules = append(registry.rules, rule)
+	}
+	for name, logger := range registry.loggers {
+		if level, ok := effectiveLevel(name); ok {
+			logger.SetLevel(level)
+		}
+	}
+}
+
+// ResetLevels removes all level rules. Registered loggers keep their current level.
+func ResetLevels() {
+	registry.mutex.Lock()
+	registry.rules = nil
+	registry.mutex.Unlock()
+}
+
+func effectiveLevel(name string) (Level, bool) {
+	best := -1
+	var level Level
+	for _, rule := range registry.rules {
+		if rule.specificity >= best && patternMatches(rule.pattern, name) {
+			best = rule.specificity
+			level = rule.level
+		}
+	}
+	return level, best >= 0
+}
+
+func patternMatches(pattern, name string) bool {
+	switch {
+	case pattern == "*" || pattern == "":
+		return true
+	case strings.HasSuffix(pattern, ".*") && !strings.Contains(pattern[:len(pattern)-2], "*"):
+		return strings.HasPrefix(name, pattern[:len(pattern)-1])
+	case strings.ContainsAny(pattern, "*?["):
+		segments := strings.Count(pattern, ".") + 1
+		parts := strings.SplitN(name, ".", segments+1)
+		if len(parts) < segments {
+			return false
+		}
+		matched, _ := path.Match(strings.ReplaceAll(pattern, ".", "/"), strings.Join(parts[:segments], "/"))
+		return matched
+	default:
+		return name == pattern || strings.HasPrefix(name, pattern+".")
+	}
+}
+
+// patternSpecificity orders rules so that deeper patterns win and wildcards lose against names of the same depth.
+func patternSpecificity(pattern string) int {
+	if pattern == "*" || pattern == "" {
+		return 0
+	}
+	specificity := 2 * (strings.Count(pattern, ".") + 1)
+	if strings.ContainsAny(pattern, "*?[") {
+		specificity--
+	}
+	return specificity
+}
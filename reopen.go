@@ -0,0 +1,41 @@
+package go_logger
+
+// Reopener is implemented by sinks that can reopen their output, e.g. after logrotate moved a file.
+type Reopener interface {
+	Reopen() error
+}
+
+func allLoggers() []*Logger {
+	loggers := []*Logger{Default()}
+	registry.mutex.Lock()
+	for _, logger := range registry.loggers {
+		loggers = append(loggers, logger)
+	}
+	registry.mutex.Unlock()
+	return loggers
+}
+
+// reopenSinks reopens every distinct sink of the loggers once. Errors are ignored, a sink that
+// cannot be reopened keeps reporting errors on write.
+func reopenSinks(loggers []*Logger) {
+	seen := make(map[Sink]bool)
+	for _, logger := range loggers {
+		sinks := logger.sinks
+		output := logger.configuredOutput()
+		if output != nil {
+			sinks = output.sinks
+		}
+		for _, sink := range sinks {
+			if seen[sink] {
+				continue
+			}
+			seen[sink] = true
+			if reopener, ok := sink.(Reopener); ok {
+				_ = reopener.Reopen()
+			}
+		}
+		if output != nil {
+			output.release()
+		}
+	}
+}
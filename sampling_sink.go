@@ -0,0 +1,137 @@
+package go_logger
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+type samplingRule struct {
+	first      int
+	thereafter int
+}
+
+type sampleKey struct {
+	level Level
+	key   string
+}
+
+type sampleCounter struct {
+	count   int
+	dropped int
+	logger  string
+}
+
+// SamplingSink passes the first N events per key and level within each tick, then every Mth. Levels
+// without a rule are not sampled. At the end of every tick a summary event "dropped N similar events"
+// is written for each key with dropped events.
+type SamplingSink struct {
+	sink     Sink
+	mutex    sync.Mutex
+	rules    map[Level]samplingRule
+	key      func(*Event) string
+	counters map[sampleKey]*sampleCounter
+	done     chan struct{}
+	stopped  sync.WaitGroup
+	once     sync.Once
+}
+
+// SampleKeyByMessage is the default key function of SamplingSink.
+func SampleKeyByMessage(event *Event) string { return event.Message }
+
+func NewSamplingSink(sink Sink, tick time.Duration) *SamplingSink {
+	sampling := &SamplingSink{
+		sink:     sink,
+		rules:    make(map[Level]samplingRule),
+		key:      SampleKeyByMessage,
+		counters: make(map[sampleKey]*sampleCounter),
+		done:     make(chan struct{}),
+	}
+	sampling.stopped.Add(1)
+	go func() {
+		defer sampling.stopped.Done()
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sampling.done:
+				return
+			case <-ticker.C:
+				sampling.summarize()
+			}
+		}
+	}()
+	return sampling
+}
+
+// Sample configures sampling for level: first events per tick pass, then every thereafter'th.
+// A thereafter of zero drops all events after the first ones.
+func (sampling *SamplingSink) Sample(level Level, first, thereafter int) *SamplingSink {
+	sampling.mutex.Lock()
+	sampling.rules[level] = samplingRule{first: first, thereafter: thereafter}
+	sampling.mutex.Unlock()
+	return sampling
+}
+
+// Key sets the function grouping similar events, e.g. by a field set by the caller.
+func (sampling *SamplingSink) Key(key func(*Event) string) *SamplingSink {
+	sampling.mutex.Lock()
+	sampling.key = key
+	sampling.mutex.Unlock()
+	return sampling
+}
+
+func (sampling *SamplingSink) Write(event *Event) error {
+	sampling.mutex.Lock()
+	rule, ok := sampling.rules[event.Level]
+	if !ok {
+		sampling.mutex.Unlock()
+		return sampling.sink.Write(event)
+	}
+	key := sampleKey{level: event.Level, key: sampling.key(event)}
+	counter := sampling.counters[key]
+	if counter == nil {
+		counter = &sampleCounter{}
+		sampling.counters[key] = counter
+	}
+	counter.count++
+	counter.logger = event.Logger
+	pass := counter.count <= rule.first ||
+		(rule.thereafter > 0 && (counter.count-rule.first)%rule.thereafter == 0)
+	if !pass {
+		counter.dropped++
+		countDropped(dropSampled)
+	}
+	sampling.mutex.Unlock()
+	if pass {
+		return sampling.sink.Write(event)
+	}
+	return nil
+}
+
+func (sampling *SamplingSink) summarize() {
+	sampling.mutex.Lock()
+	counters := sampling.counters
+	sampling.counters = make(map[sampleKey]*sampleCounter, len(counters))
+	sampling.mutex.Unlock()
+	for key, counter := range counters {
+		if counter.dropped == 0 {
+			continue
+		}
+		event := createEvent(key.level, "dropped "+strconv.Itoa(counter.dropped)+" similar events", nil)
+		event.Logger = counter.logger
+		event.Fields = []Field{String("sampleKey", key.key), Int("dropped", counter.dropped)}
+		_ = sampling.sink.Write(event)
+		releaseEvent(event)
+	}
+}
+
+func (sampling *SamplingSink) Flush() error { return sampling.sink.Flush() }
+
+// Close writes the pending summaries and closes the wrapped sink.
+func (sampling *SamplingSink) Close() error {
+	sampling.once.Do(func() { close(sampling.done) })
+	sampling.stopped.Wait()
+	sampling.summarize()
+	return sampling.sink.Close()
+}
@@ -0,0 +1,110 @@
+package go_logger_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestSamplingSink(t *testing.T) {
+	tests := []struct {
+		name       string
+		first      int
+		thereafter int
+		key        func(*golog.Event) string
+		messages   string
+		want       []string
+	}{
+		{
+			name:     "first only",
+			first:    2,
+			messages: "a a a a",
+			want:     []string{"a", "a", "dropped 2 similar events"},
+		},
+		{
+			name:       "every thereafter",
+			first:      1,
+			thereafter: 2,
+			messages:   "a a a a a",
+			want:       []string{"a", "a", "a", "dropped 2 similar events"},
+		},
+		{
+			name:     "per message",
+			first:    1,
+			messages: "a b a b c",
+			want:     []string{"a", "b", "c", "dropped 1 similar events", "dropped 1 similar events"},
+		},
+		{
+			name:     "custom key",
+			first:    1,
+			key:      func(event *golog.Event) string { return event.Message[:1] },
+			messages: "a1 a2 b1",
+			want:     []string{"a1", "b1", "dropped 1 similar events"},
+		},
+		{
+			name:     "nothing dropped",
+			first:    3,
+			messages: "a b a",
+			want:     []string{"a", "b", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer := logtest.NewObserver()
+			sampling := golog.NewSamplingSink(observer, time.Hour).Sample(golog.INFO, tt.first, tt.thereafter)
+			if tt.key != nil {
+				sampling.Key(tt.key)
+			}
+			for _, msg := range strings.Fields(tt.messages) {
+				_ = sampling.Write(&golog.Event{Level: golog.INFO, Message: msg})
+			}
+			// unsampled levels always pass
+			_ = sampling.Write(&golog.Event{Level: golog.ERROR, Message: "error"})
+			_ = sampling.Write(&golog.Event{Level: golog.ERROR, Message: "error"})
+			if err := sampling.Close(); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, event := range observer.All() {
+				if event.Level == golog.INFO {
+					got = append(got, event.Message)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("written %q, want %q", got, tt.want)
+			}
+			if errors := observer.FilterLevel(golog.ERROR).Len(); errors != 2 {
+				t.Errorf("%d ERROR events, want 2", errors)
+			}
+		})
+	}
+}
+
+func TestSamplingSinkTick(t *testing.T) {
+	observer := logtest.NewObserver()
+	sampling := golog.NewSamplingSink(observer, 10*time.Millisecond).Sample(golog.INFO, 1, 0)
+	defer sampling.Close()
+	for i := 0; i < 3; i++ {
+		_ = sampling.Write(&golog.Event{Level: golog.INFO, Logger: "api", Message: "a"})
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for observer.FilterMessage("dropped 2 similar events").Len() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no summary written at the end of the tick")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	summary := observer.FilterMessage("dropped 2 similar events").All()[0]
+	if summary.Logger != "api" || len(summary.Fields) != 2 || summary.Fields[0].String != "a" {
+		t.Errorf("summary %+v, want logger api and the sample key a", summary)
+	}
+	// counting starts over after a tick
+	_ = sampling.Write(&golog.Event{Level: golog.INFO, Message: "a"})
+	if got := observer.FilterMessage("a").Len(); got != 2 {
+		t.Errorf("%d events passed, want 2", got)
+	}
+}
@@ -0,0 +1,60 @@
+//go:build unix
+
+package go_logger
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// HandleSignals installs a signal listener for long-running daemons: SIGHUP reopens all sinks that
+// implement Reopener (for logrotate), SIGUSR1 switches the loggers to DEBUG and SIGUSR2 restores the
+// levels they had before. Without arguments the default logger and all registered loggers are
+// controlled. The returned function stops listening and may be called more than once.
+func HandleSignals(loggers ...*Logger) func() {
+	signals := make(chan os.Signal, 4)
+	signal.Notify(signals, syscall.SIGHUP, syscall.SIGUSR1, syscall.SIGUSR2)
+	done := make(chan struct{})
+	go func() {
+		var previous map[*Logger]Level
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-signals:
+				targets := loggers
+				if len(targets) == 0 {
+					targets = allLoggers()
+				}
+				switch sig {
+				case syscall.SIGHUP:
+					reopenSinks(targets)
+				case syscall.SIGUSR1:
+					if previous == nil {
+						previous = make(map[*Logger]Level, len(targets))
+						for _, logger := range targets {
+							previous[logger] = logger.GetLevel()
+						}
+					}
+					for _, logger := range targets {
+						logger.SetLevel(DEBUG)
+					}
+				case syscall.SIGUSR2:
+					for logger, level := range previous {
+						logger.SetLevel(level)
+					}
+					previous = nil
+				}
+			}
+		}
+	}()
+	var stopOnce sync.Once
+	return func() {
+		stopOnce.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
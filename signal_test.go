@@ -0,0 +1,42 @@
+//go:build unix
+
+package go_logger_test
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestHandleSignals(t *testing.T) {
+	logger := golog.NewLogger("signals").Level(golog.WARN)
+	stop := golog.HandleSignals(logger)
+	defer stop()
+	waitForLevel := func(want golog.Level) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for logger.GetLevel() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("level is %v, want %v", logger.GetLevel(), want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	waitForLevel(golog.DEBUG)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatal(err)
+	}
+	waitForLevel(golog.WARN)
+}
+
+func TestHandleSignalsStopTwice(t *testing.T) {
+	stop := golog.HandleSignals(golog.NewLogger("signals"))
+	stop()
+	stop()
+}
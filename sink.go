@@ -0,0 +1,119 @@
+package go_logger
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// Sink receives every event that passes the level of the logger. Sinks must be safe for concurrent use.
+// Events are reused after Write returns; sinks keeping an event have to keep a copy.
+type Sink interface {
+	Write(event *Event) error
+	Flush() error
+	Close() error
+}
+
+// AddSink adds a sink to the logger. As soon as a logger has sinks, events are written to all of them
+// instead of to Out. The logger level stays the global minimum; sinks may filter further.
+func (logger *Logger) AddSink(sink Sink) *Logger {
+	logger = logger.derive()
+	logger.followsConfig = false
+	logger.sinks = append(logger.sinks[:len(logger.sinks):len(logger.sinks)], sink)
+	return logger
+}
+
+// Sinks replaces all sinks of the logger.
+func (logger *Logger) Sinks(sinks ...Sink) *Logger {
+	logger = logger.derive()
+	logger.followsConfig = false
+	logger.sinks = sinks
+	return logger
+}
+
+// WriterSink encodes events with its own encoder and level and writes them to an io.Writer.
+type WriterSink struct {
+	mutex   sync.Mutex
+	out     io.Writer
+	encoder Encoder
+	level   Level
+}
+
+func NewWriterSink(out io.Writer, encoder Encoder) *WriterSink {
+	return &WriterSink{out: out, encoder: encoder, level: TRACE}
+}
+
+func (sink *WriterSink) Level(level Level) *WriterSink {
+	sink.level = level
+	return sink
+}
+
+func (sink *WriterSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	buf := encodeEvent(sink.encoder, event)
+	defer putBuffer(buf)
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	return writeFullBytes(sink.out, *buf)
+}
+
+func (sink *WriterSink) Flush() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	switch out := sink.out.(type) {
+	case interface{ Flush() error }:
+		return out.Flush()
+	case interface{ Sync() error }:
+		if out == os.Stdout || out == os.Stderr {
+			return nil
+		}
+		return out.Sync()
+	}
+	return nil
+}
+
+// Close flushes and closes the underlying writer if it is an io.Closer. os.Stdout and os.Stderr are never closed.
+func (sink *WriterSink) Close() error {
+	err := sink.Flush()
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if out, ok := sink.out.(io.Closer); ok && out != os.Stdout && out != os.Stderr {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// writeFull writes s as a whole to out. Writers violating io.Writer by returning short writes without
+// an error get the rest until they stop making progress, which fails with io.ErrShortWrite.
+func writeFull(out io.Writer, s string) error {
+	for len(s) > 0 {
+		n, err := io.WriteString(out, s)
+		if err != nil {
+			return err
+		}
+		if n <= 0 || n > len(s) {
+			return io.ErrShortWrite
+		}
+		s = s[n:]
+	}
+	return nil
+}
+
+// writeFullBytes is writeFull for a byte slice.
+func writeFullBytes(out io.Writer, p []byte) error {
+	for len(p) > 0 {
+		n, err := out.Write(p)
+		if err != nil {
+			return err
+		}
+		if n <= 0 || n > len(p) {
+			return io.ErrShortWrite
+		}
+		p = p[n:]
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package go_logger
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// SlogSink forwards events to a slog.Handler, so the API of this package can sit on top of an
+// existing slog pipeline. Logger name, goroutine, error, caller and fields become attributes.
+type SlogSink struct {
+	handler slog.Handler
+}
+
+func NewSlogSink(handler slog.Handler) *SlogSink {
+	return &SlogSink{handler: handler}
+}
+
+func SlogLevel(level Level) slog.Level {
+	switch level {
+	case TRACE:
+		return slog.LevelDebug - 4
+	case DEBUG:
+		return slog.LevelDebug
+	case INFO:
+		return slog.LevelInfo
+	case WARN:
+		return slog.LevelWarn
+	case ERROR:
+		return slog.LevelError
+	default:
+		return slog.LevelError + 4
+	}
+}
+
+func (sink *SlogSink) Write(event *Event) error {
+	ctx := context.Background()
+	level := SlogLevel(event.Level)
+	if !sink.handler.Enabled(ctx, level) {
+		return nil
+	}
+	record := slog.NewRecord(event.Timestamp, level, event.Message, 0)
+	record.AddAttrs(slog.String("logger", event.Logger))
+	if event.GoroutineId != "" {
+		record.AddAttrs(slog.String("goroutine", event.GoroutineId))
+	}
+	if event.Err != nil {
+		record.AddAttrs(slog.String("error", event.Err.Error()))
+	}
+	if event.Caller.Defined() {
+		record.AddAttrs(slog.String("caller", event.Caller.String()))
+	}
+	for _, field := range event.Fields {
+		record.AddAttrs(field.slogAttr())
+	}
+	return sink.handler.Handle(ctx, record)
+}
+
+func (sink *SlogSink) Flush() error { return nil }
+func (sink *SlogSink) Close() error { return nil }
+
+func (field Field) slogAttr() slog.Attr {
+	switch field.Type {
+	case StringType:
+		return slog.String(field.Key, field.String)
+	case IntType:
+		return slog.Int64(field.Key, field.Integer)
+	case UintType:
+		return slog.Uint64(field.Key, uint64(field.Integer))
+	case FloatType:
+		return slog.Float64(field.Key, math.Float64frombits(uint64(field.Integer)))
+	case BoolType:
+		return slog.Bool(field.Key, field.Integer != 0)
+	case DurationType:
+		return slog.Duration(field.Key, time.Duration(field.Integer))
+	case TimeType:
+		return slog.Time(field.Key, field.time())
+	case ErrorType:
+		if err, ok := field.Interface.(error); ok && err != nil {
+			return slog.String(field.Key, err.Error())
+		}
+		return slog.Any(field.Key, nil)
+	case ObjectType:
+		fields, _ := field.Interface.([]Field)
+		attrs := make([]any, len(fields))
+		for i, f := range fields {
+			attrs[i] = f.slogAttr()
+		}
+		return slog.Group(field.Key, attrs...)
+	case ArrayType:
+		fields, _ := field.Interface.([]Field)
+		values := make([]any, len(fields))
+		for i, f := range fields {
+			values[i] = f.slogAttr().Value.Any()
+		}
+		return slog.Any(field.Key, values)
+	default:
+		return slog.Any(field.Key, field.Interface)
+	}
+}
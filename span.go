@@ -0,0 +1,40 @@
+package go_logger
+
+import "time"
+
+// Start logs the start of operation at DEBUG and returns a function logging its end with the elapsed
+// time: at INFO, or at ERROR together with err if it is not nil. Both events carry the field
+// operation. To report the error of a function, defer a closure: defer func() { done(err) }().
+func (logger *Logger) Start(operation string) func(err error) {
+	logger.logOperation(DEBUG, operation+" started", operation, nil, -1)
+	start := logger.now()
+	return func(err error) {
+		elapsed := logger.now().Sub(start)
+		if err != nil {
+			logger.logOperation(ERROR, operation+" failed", operation, err, elapsed)
+		} else {
+			logger.logOperation(INFO, operation+" finished", operation, nil, elapsed)
+		}
+	}
+}
+
+// logOperation logs an event of Start; a negative elapsed is omitted.
+func (logger *Logger) logOperation(level Level, msg, operation string, err error, elapsed time.Duration) {
+	if !logger.enabled(level) {
+		return
+	}
+	event := createEvent(level, msg, err)
+	fields := append(logger.fields[:len(logger.fields):len(logger.fields)], String("operation", operation))
+	if elapsed >= 0 {
+		fields = append(fields, Duration("duration", elapsed))
+	}
+	event.Fields = fields
+	logger.log(event)
+}
+
+func (logger *Logger) now() time.Time {
+	if logger.clock != nil {
+		return logger.clock.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,79 @@
+package go_logger
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// stackError is an error annotated with the stack of its creation by WrapErr.
+type stackError struct {
+	msg string
+	err error
+	pcs []uintptr
+}
+
+// WrapErr annotates err with msg and the stack of the caller. Logged errors carrying a stack anywhere in
+// their chain have the stack of the innermost one written with the event. A nil err returns nil, an
+// empty msg keeps the message of err.
+func WrapErr(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	return &stackError{msg: msg, err: err, pcs: pcs[:n]}
+}
+
+func (err *stackError) Error() string {
+	if err.msg == "" {
+		return err.err.Error()
+	}
+	return err.msg + ": " + err.err.Error()
+}
+func (err *stackError) Unwrap() error { return err.err }
+
+// StackTrace returns the stack the error was created with.
+func (err *stackError) StackTrace() []Caller { return stackOf(err.pcs) }
+
+// errorStack returns the stack of the innermost error in the chain of err carrying one, either created
+// by WrapErr or implementing StackTrace() like github.com/pkg/errors.
+func errorStack(err error) []Caller {
+	var stack []Caller
+	for _, e := range append([]error{err}, errorCauses(err)...) {
+		if s := stackOfError(e); s != nil {
+			stack = s
+		}
+	}
+	return stack
+}
+
+func stackOfError(err error) []Caller {
+	if tracer, ok := err.(interface{ StackTrace() []Caller }); ok {
+		return tracer.StackTrace()
+	}
+	// pkg/errors returns a StackTrace, which is a slice of program counters of type Frame.
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+	out := method.Type().Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+	frames := method.Call(nil)[0]
+	pcs := make([]uintptr, frames.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(frames.Index(i).Uint())
+	}
+	return stackOf(pcs)
+}
+
+// writeErrorStack writes the stack of err, if any, in the layout of event stacks.
+func writeErrorStack(sb *buffer, err error) {
+	stack := errorStack(err)
+	if len(stack) == 0 {
+		return
+	}
+	sb.WriteString("\n\terror stack:")
+	writeStackFrames(sb, stack, "\t\t")
+}
@@ -0,0 +1,62 @@
+package go_logger
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+)
+
+// Stats is a snapshot of the counters of all loggers sharing a name.
+type Stats struct {
+	Emitted     uint64 `json:"emitted"`
+	Filtered    uint64 `json:"filtered"`
+	Dropped     uint64 `json:"dropped"`
+	WriteErrors uint64 `json:"writeErrors"`
+}
+
+type loggerStats struct {
+	emitted     atomic.Uint64
+	filtered    atomic.Uint64
+	dropped     atomic.Uint64
+	writeErrors atomic.Uint64
+}
+
+func (stats *loggerStats) snapshot() Stats {
+	if stats == nil {
+		return Stats{}
+	}
+	return Stats{
+		Emitted:     stats.emitted.Load(),
+		Filtered:    stats.filtered.Load(),
+		Dropped:     stats.dropped.Load(),
+		WriteErrors: stats.writeErrors.Load(),
+	}
+}
+
+var allStats sync.Map // name -> *loggerStats
+
+// The counters of all loggers are published as expvar "go_logger", a map from logger name to Stats.
+func init() {
+	expvar.Publish("go_logger", expvar.Func(func() any {
+		snapshot := map[string]Stats{}
+		allStats.Range(func(name, stats any) bool {
+			snapshot[name.(string)] = stats.(*loggerStats).snapshot()
+			return true
+		})
+		return snapshot
+	}))
+}
+
+func statsFor(name string) *loggerStats {
+	if stats, ok := allStats.Load(name); ok {
+		return stats.(*loggerStats)
+	}
+	stats, _ := allStats.LoadOrStore(name, &loggerStats{})
+	return stats.(*loggerStats)
+}
+
+// Stats returns the events emitted, dropped by filters, dropped by the rate limit and failed writes of
+// all loggers with the name of this logger.
+func (logger *Logger) Stats() Stats {
+	return logger.stats.snapshot()
+}
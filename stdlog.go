@@ -0,0 +1,32 @@
+package go_logger
+
+import (
+	"log"
+	"strings"
+)
+
+// StdLogger returns a *log.Logger writing every line as event at level, for code requiring the
+// standard logger such as http.Server.ErrorLog. Prefix and flags of the returned logger are empty,
+// as timestamp and goroutine are added by this logger.
+func (logger *Logger) StdLogger(level Level) *log.Logger {
+	return log.New(&stdLogWriter{logger: logger, level: level}, "", 0)
+}
+
+type stdLogWriter struct {
+	logger *Logger
+	level  Level
+}
+
+// Write is called by log.Logger once per line, in the goroutine of the caller.
+func (writer *stdLogWriter) Write(p []byte) (int, error) {
+	if !writer.logger.enabled(writer.level) {
+		return len(p), nil
+	}
+	event := createEvent(writer.level, strings.TrimSuffix(string(p), "\n"), nil)
+	if writer.logger.caller {
+		// skip log.(*Logger).output and the print method of log.Logger or the log package
+		event.Caller = captureCaller(writer.logger.callerSkip + 2)
+	}
+	writer.logger.log(event)
+	return len(p), nil
+}
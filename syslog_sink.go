@@ -0,0 +1,297 @@
+package go_logger
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type SyslogFormat int
+
+const (
+	RFC3164 SyslogFormat = iota
+	RFC5424
+)
+
+type Facility int
+
+//goland:noinspection GoUnusedConst
+const (
+	FacilityKern Facility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLpr
+	FacilityNews
+	FacilityUucp
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFtp
+	FacilityLocal0 Facility = iota + 4
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// sdID is the SD-ID of the structured data element carrying the fields in RFC 5424 messages.
+// 32473 is the private enterprise number reserved for documentation.
+const sdID = "fields@32473"
+
+// rfc5424Time is the RFC 5424 TIMESTAMP, whose TIME-SECFRAC allows at most microseconds.
+const rfc5424Time = "2006-01-02T15:04:05.000000Z07:00"
+
+// SyslogSink sends events to a syslog daemon over a unix socket, UDP or TCP. Over TCP, RFC 5424
+// messages are framed by octet counting and RFC 3164 messages by a trailing newline.
+type SyslogSink struct {
+	mutex    sync.Mutex
+	network  string
+	address  string
+	format   SyslogFormat
+	facility Facility
+	tag      string
+	hostname string
+	level    Level
+	conn     net.Conn
+	closed   bool
+}
+
+// NewSyslogSink connects to the syslog daemon at address. An empty network connects to the local
+// daemon via /dev/log, /var/run/syslog or /var/run/log.
+func NewSyslogSink(network, address string) (*SyslogSink, error) {
+	hostname, _ := os.Hostname()
+	sink := &SyslogSink{
+		network:  network,
+		address:  address,
+		facility: FacilityUser,
+		tag:      os.Args[0][strings.LastIndexAny(os.Args[0], `/\`)+1:],
+		hostname: hostname,
+		level:    TRACE,
+	}
+	if err := sink.connect(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+func (sink *SyslogSink) Format(format SyslogFormat) *SyslogSink {
+	sink.format = format
+	return sink
+}
+func (sink *SyslogSink) Facility(facility Facility) *SyslogSink {
+	sink.facility = facility
+	return sink
+}
+func (sink *SyslogSink) Tag(tag string) *SyslogSink {
+	sink.tag = tag
+	return sink
+}
+func (sink *SyslogSink) Level(level Level) *SyslogSink {
+	sink.level = level
+	return sink
+}
+
+func (sink *SyslogSink) connect() error {
+	if sink.network != "" {
+		conn, err := net.Dial(sink.network, sink.address)
+		if err != nil {
+			return err
+		}
+		sink.conn = conn
+		return nil
+	}
+	for _, path := range []string{"/dev/log", "/var/run/syslog", "/var/run/log"} {
+		for _, network := range []string{"unixgram", "unix"} {
+			if conn, err := net.Dial(network, path); err == nil {
+				sink.conn = conn
+				return nil
+			}
+		}
+	}
+	return errors.New("go_logger: no local syslog daemon found")
+}
+
+// SyslogSeverity maps a level to the syslog severity.
+func SyslogSeverity(level Level) int {
+	switch level {
+	case TRACE, DEBUG:
+		return 7
+	case INFO:
+		return 6
+	case WARN:
+		return 4
+	case ERROR:
+		return 3
+	default:
+		return 2
+	}
+}
+
+func (sink *SyslogSink) Write(event *Event) error {
+	if event.Level < sink.level {
+		return nil
+	}
+	msg := sink.message(event)
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.closed {
+		return ErrSinkClosed
+	}
+	if sink.conn == nil {
+		if err := sink.connect(); err != nil {
+			return err
+		}
+	}
+	if _, err := sink.conn.Write(msg); err != nil {
+		_ = sink.conn.Close()
+		sink.conn = nil
+		if err := sink.connect(); err != nil {
+			return err
+		}
+		_, err = sink.conn.Write(msg)
+		return err
+	}
+	return nil
+}
+
+func (sink *SyslogSink) message(event *Event) []byte {
+	sb := buffer{}
+	sb.WriteByte('<')
+	sb.WriteString(strconv.Itoa(int(sink.facility)*8 + SyslogSeverity(event.Level)))
+	sb.WriteByte('>')
+	pid := strconv.Itoa(os.Getpid())
+	if sink.format == RFC5424 {
+		sb.WriteString("1 ")
+		sb.WriteString(event.Timestamp.Format(rfc5424Time))
+		sb.WriteByte(' ')
+		sb.WriteString(syslogHeaderValue(sink.hostname, 255))
+		sb.WriteByte(' ')
+		sb.WriteString(syslogHeaderValue(sink.tag, 48))
+		sb.WriteByte(' ')
+		sb.WriteString(pid)
+		sb.WriteByte(' ')
+		sb.WriteString(syslogHeaderValue(event.Logger, 32))
+		sb.WriteByte(' ')
+		writeStructuredData(&sb, event.Fields)
+		sb.WriteByte(' ')
+		writeSyslogBody(&sb, event)
+	} else {
+		sb.WriteString(event.Timestamp.Format(time.Stamp))
+		sb.WriteByte(' ')
+		sb.WriteString(sink.hostname)
+		sb.WriteByte(' ')
+		sb.WriteString(sink.tag)
+		sb.WriteByte('[')
+		sb.WriteString(pid)
+		sb.WriteString("]: ")
+		writeSyslogBody(&sb, event)
+		for _, field := range event.Fields {
+			sb.WriteByte(' ')
+			sb.WriteString(field.Key)
+			sb.WriteByte('=')
+			field.writeText(&sb)
+		}
+	}
+	msg := sb.String()
+	switch {
+	case !strings.HasPrefix(sink.network, "tcp"):
+		return []byte(msg)
+	case sink.format == RFC5424:
+		return []byte(strconv.Itoa(len(msg)) + " " + msg)
+	default:
+		return []byte(msg + "\n")
+	}
+}
+
+func writeSyslogBody(sb *buffer, event *Event) {
+	if event.Logger != "" {
+		sb.WriteByte('[')
+		sb.WriteString(event.Logger)
+		sb.WriteString("] ")
+	}
+	sb.WriteString(event.Message)
+	if event.Err != nil {
+		sb.WriteString(": ")
+		sb.WriteString(event.Err.Error())
+	}
+}
+
+func writeStructuredData(sb *buffer, fields []Field) {
+	if len(fields) == 0 {
+		sb.WriteByte('-')
+		return
+	}
+	sb.WriteByte('[')
+	sb.WriteString(sdID)
+	for _, field := range fields {
+		sb.WriteByte(' ')
+		sb.WriteString(syslogParamName(field.Key))
+		sb.WriteString("=\"")
+		value := buffer{}
+		field.writeText(&value)
+		for _, r := range value.String() {
+			if r == '"' || r == '\\' || r == ']' {
+				sb.WriteByte('\\')
+			}
+			sb.WriteRune(r)
+		}
+		sb.WriteByte('"')
+	}
+	sb.WriteByte(']')
+}
+
+// syslogHeaderValue returns value restricted to printable US-ASCII and maxLength, or the nil value "-".
+func syslogHeaderValue(value string, maxLength int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, value)
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func syslogParamName(key string) string {
+	key = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, key)
+	if len(key) > 32 {
+		key = key[:32]
+	}
+	if key == "" {
+		return "_"
+	}
+	return key
+}
+
+func (sink *SyslogSink) Flush() error { return nil }
+
+func (sink *SyslogSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	sink.closed = true
+	if sink.conn == nil {
+		return nil
+	}
+	err := sink.conn.Close()
+	sink.conn = nil
+	return err
+}
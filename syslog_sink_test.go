@@ -0,0 +1,129 @@
+package go_logger_test
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestSyslogSink(t *testing.T) {
+	hostname, _ := os.Hostname()
+	pid := strconv.Itoa(os.Getpid())
+	event := &golog.Event{
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC),
+		Level:     golog.WARN,
+		Logger:    "db",
+		Message:   "slow query",
+		Fields:    []golog.Field{golog.String("table", `a"b]`), golog.Int("ms", 250)},
+	}
+	rfc5424 := "<12>1 2024-01-02T03:04:05.123456Z " + hostname + " app " + pid +
+		` db [fields@32473 table="a\"b\]" ms="250"] [db] slow query`
+	rfc3164 := "<12>Jan  2 03:04:05 " + hostname + " app[" + pid + "]: [db] slow query table=a\"b] ms=250"
+	tests := []struct {
+		name    string
+		network string
+		format  golog.SyslogFormat
+		want    string
+	}{
+		{name: "RFC 5424 over UDP", network: "udp", format: golog.RFC5424, want: rfc5424},
+		{name: "RFC 5424 over TCP", network: "tcp", format: golog.RFC5424, want: strconv.Itoa(len(rfc5424)) + " " + rfc5424},
+		{name: "RFC 3164 over UDP", network: "udp", format: golog.RFC3164, want: rfc3164},
+		{name: "RFC 3164 over TCP", network: "tcp", format: golog.RFC3164, want: rfc3164 + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received := make(chan string, 1)
+			var address string
+			if tt.network == "udp" {
+				conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer conn.Close()
+				address = conn.LocalAddr().String()
+				go func() {
+					packet := make([]byte, 4096)
+					n, _, _ := conn.ReadFrom(packet)
+					received <- string(packet[:n])
+				}()
+			} else {
+				listener, err := net.Listen("tcp", "127.0.0.1:0")
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer listener.Close()
+				address = listener.Addr().String()
+				go func() {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+					defer conn.Close()
+					packet := make([]byte, 4096)
+					n, _ := conn.Read(packet)
+					received <- string(packet[:n])
+				}()
+			}
+			sink, err := golog.NewSyslogSink(tt.network, address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sink.Close()
+			sink.Format(tt.format).Facility(golog.FacilityUser).Tag("app")
+			if err := sink.Write(event); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case got := <-received:
+				if got != tt.want {
+					t.Errorf("message\n got %q\nwant %q", got, tt.want)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("no message received")
+			}
+		})
+	}
+}
+
+func TestSyslogSinkRFC5424Timestamp(t *testing.T) {
+	// TIME-SECFRAC allows at most 6 digits, and the nanoseconds must not leak into it
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	sink, err := golog.NewSyslogSink("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	sink.Format(golog.RFC5424)
+	zone := time.FixedZone("CEST", 2*60*60)
+	tests := []struct {
+		timestamp time.Time
+		want      string
+	}{
+		{time.Date(2024, 1, 2, 3, 4, 5, 999999999, time.UTC), "2024-01-02T03:04:05.999999Z"},
+		{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "2024-01-02T03:04:05.000000Z"},
+		{time.Date(2024, 7, 2, 3, 4, 5, 1000, zone), "2024-07-02T03:04:05.000001+02:00"},
+	}
+	for _, tt := range tests {
+		if err := sink.Write(&golog.Event{Timestamp: tt.timestamp, Level: golog.INFO, Message: "m"}); err != nil {
+			t.Fatal(err)
+		}
+		packet := make([]byte, 4096)
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(packet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fields := strings.Fields(string(packet[:n])); len(fields) < 2 || fields[1] != tt.want {
+			t.Errorf("timestamp of %q, want %s", packet[:n], tt.want)
+		}
+	}
+}
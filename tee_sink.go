@@ -0,0 +1,55 @@
+package go_logger
+
+import "errors"
+
+// TeeSink writes every event to all of its sinks. A failing sink does not keep the event from the
+// others; its error is reported to the error callback and included in the joined error returned.
+type TeeSink struct {
+	sinks   []Sink
+	onError func(sink Sink, err error, event *Event)
+}
+
+func NewTeeSink(sinks ...Sink) *TeeSink {
+	return &TeeSink{sinks: sinks}
+}
+
+// OnError sets a callback for errors of single sinks. The event is nil for errors of Flush and Close.
+func (tee *TeeSink) OnError(callback func(sink Sink, err error, event *Event)) *TeeSink {
+	tee.onError = callback
+	return tee
+}
+
+func (tee *TeeSink) Write(event *Event) error {
+	return tee.each(event, func(sink Sink) error { return sink.Write(event) })
+}
+
+func (tee *TeeSink) Flush() error {
+	return tee.each(nil, Sink.Flush)
+}
+
+func (tee *TeeSink) Close() error {
+	return tee.each(nil, Sink.Close)
+}
+
+// Reopen reopens all sinks implementing Reopener.
+func (tee *TeeSink) Reopen() error {
+	return tee.each(nil, func(sink Sink) error {
+		if reopener, ok := sink.(Reopener); ok {
+			return reopener.Reopen()
+		}
+		return nil
+	})
+}
+
+func (tee *TeeSink) each(event *Event, f func(Sink) error) error {
+	var errs []error
+	for _, sink := range tee.sinks {
+		if err := f(sink); err != nil {
+			errs = append(errs, err)
+			if tee.onError != nil {
+				tee.onError(sink, err, event)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,53 @@
+package go_logger_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+)
+
+func TestTeeSink(t *testing.T) {
+	down := errors.New("connection refused")
+	tests := []struct {
+		name       string
+		sinks      []*flakySink
+		wantErrors int
+	}{
+		{name: "all healthy", sinks: []*flakySink{{}, {}}},
+		{name: "first failing", sinks: []*flakySink{{err: down}, {}}, wantErrors: 1},
+		{name: "all failing", sinks: []*flakySink{{err: down}, {err: down}}, wantErrors: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sinks := make([]golog.Sink, len(tt.sinks))
+			for i, sink := range tt.sinks {
+				sinks[i] = sink
+			}
+			var reported []*golog.Event
+			tee := golog.NewTeeSink(sinks...).OnError(func(sink golog.Sink, err error, event *golog.Event) {
+				if !errors.Is(err, down) {
+					t.Errorf("OnError got %v, want %v", err, down)
+				}
+				reported = append(reported, event)
+			})
+			err := tee.Write(&golog.Event{Level: golog.INFO, Message: "1"})
+			if (err != nil) != (tt.wantErrors > 0) || err != nil && !errors.Is(err, down) {
+				t.Errorf("Write = %v, want %d joined errors", err, tt.wantErrors)
+			}
+			if len(reported) != tt.wantErrors {
+				t.Errorf("OnError called %d times, want %d", len(reported), tt.wantErrors)
+			}
+			for i, sink := range tt.sinks {
+				want := []string{"1"}
+				if sink.err != nil {
+					want = nil
+				}
+				if !slices.Equal(sink.messages, want) {
+					t.Errorf("sink %d got %q, want %q", i, sink.messages, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package go_logger
+
+import (
+	"os"
+	"strings"
+	"text/template"
+)
+
+// TemplateEncoder renders events with a text/template compiled once by NewTemplateEncoder. The
+// template is executed with a TemplateData, e.g.
+//
+//	{{.Timestamp.Format "15:04:05"}} {{pad 5 .Level.Long}} [{{.Logger}}] {{.Message}}{{with .Err}}: {{.}}{{end}} {{fields .Fields}}
+//
+// Besides the functions of text/template it provides pad, upper, lower, text (the value of a field)
+// and fields (all fields as key=value). A newline is appended unless the output ends with one.
+type TemplateEncoder struct {
+	template *template.Template
+	host     string
+}
+
+// TemplateData is the data a TemplateEncoder executes its template with.
+type TemplateData struct {
+	*Event
+	Host string
+}
+
+// Field returns the value of the first field with key as text or "" if there is none.
+func (data TemplateData) Field(key string) string {
+	for _, field := range data.Fields {
+		if field.Key == key {
+			return field.text()
+		}
+	}
+	return ""
+}
+
+var templateFuncs = template.FuncMap{
+	"pad": func(width int, s string) string {
+		if width < 0 {
+			return stringToLength(s, -width)
+		}
+		if len(s) < width {
+			return s + strings.Repeat(" ", width-len(s))
+		}
+		return s
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"text":  func(field Field) string { return field.text() },
+	"fields": func(fields []Field) string {
+		sb := buffer{}
+		for i, field := range fields {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(field.Key)
+			sb.WriteByte('=')
+			field.writeText(&sb)
+		}
+		return sb.String()
+	},
+}
+
+func NewTemplateEncoder(text string) (*TemplateEncoder, error) {
+	tmpl, err := template.New("event").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	return &TemplateEncoder{template: tmpl, host: host}, nil
+}
+
+// MustTemplateEncoder is NewTemplateEncoder panicking on an invalid template.
+func MustTemplateEncoder(text string) *TemplateEncoder {
+	encoder, err := NewTemplateEncoder(text)
+	if err != nil {
+		panic(err)
+	}
+	return encoder
+}
+
+func (encoder *TemplateEncoder) Encode(sb *strings.Builder, event *Event) {
+	encodeTo(sb, encoder, event)
+}
+
+func (encoder *TemplateEncoder) encode(sb *buffer, event *Event) {
+	start := sb.Len()
+	if err := encoder.template.Execute(sb, TemplateData{Event: event, Host: encoder.host}); err != nil {
+		sb.WriteString("go_logger: ")
+		sb.WriteString(err.Error())
+	}
+	if sb.Len() == start || !strings.HasSuffix(sb.String(), "\n") {
+		sb.WriteByte('\n')
+	}
+}
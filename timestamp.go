@@ -0,0 +1,152 @@
+package go_logger
+
+import (
+	"strconv"
+	"time"
+)
+
+// Layouts for TimestampFormat writing the time since the Unix epoch. JSON encoders write them as numbers.
+const (
+	TimestampUnix      = "unix"
+	TimestampUnixMilli = "unixmilli"
+	TimestampUnixMicro = "unixmicro"
+	TimestampUnixNano  = "unixnano"
+)
+
+// TimestampElapsed is the layout writing the time elapsed since an origin, e.g. "+0012.348s". The
+// origin is the start of the process unless set by ElapsedSince.
+const TimestampElapsed = "elapsed"
+
+var processStart = time.Now()
+
+// ElapsedSince writes timestamps as time elapsed since origin, e.g. ElapsedSince(time.Now()) right
+// after creating the logger.
+func (logger *Logger) ElapsedSince(origin time.Time) *Logger {
+	logger = logger.derive()
+	logger.timeLayout = TimestampElapsed
+	logger.timeOrigin = origin
+	return logger
+}
+
+// RFC3339 layouts with a fixed number of fractional digits, so that timestamps line up in columns.
+const (
+	RFC3339Milli = "2006-01-02T15:04:05.000Z07:00"
+	RFC3339Micro = "2006-01-02T15:04:05.000000Z07:00"
+	RFC3339Nano  = "2006-01-02T15:04:05.000000000Z07:00"
+)
+
+type Precision int
+
+const (
+	Seconds Precision = iota
+	Milliseconds
+	Microseconds
+	Nanoseconds
+)
+
+// TimestampPrecision sets the fractional digits of the default RFC 3339 timestamps. It is ignored if
+// a layout was set with TimestampFormat.
+func (logger *Logger) TimestampPrecision(precision Precision) *Logger {
+	logger = logger.derive()
+	logger.timePrecision = precision
+	return logger
+}
+
+// timestampLayout returns the layout set by TimestampFormat or derived from the precision.
+func (logger *Logger) timestampLayout() string {
+	if logger.timeLayout != "" {
+		return logger.timeLayout
+	}
+	switch logger.timePrecision {
+	case Milliseconds:
+		return RFC3339Milli
+	case Microseconds:
+		return RFC3339Micro
+	case Nanoseconds:
+		return RFC3339Nano
+	default:
+		return ""
+	}
+}
+
+// TimestampFormat sets the layout of the timestamps written by the plain and JSON format, time.RFC3339
+// by default. Besides Go time layouts the epoch layouts TimestampUnix, TimestampUnixMilli,
+// TimestampUnixMicro and TimestampUnixNano are supported.
+func (logger *Logger) TimestampFormat(layout string) *Logger {
+	logger = logger.derive()
+	logger.timeLayout = layout
+	return logger
+}
+
+// TimeZone converts timestamps to loc before formatting. Nil keeps the location of the clock.
+func (logger *Logger) TimeZone(loc *time.Location) *Logger {
+	logger = logger.derive()
+	logger.timeLocation = loc
+	return logger
+}
+
+// UTC writes timestamps in UTC, or in the location of the clock again.
+func (logger *Logger) UTC(utc bool) *Logger {
+	logger = logger.derive()
+	if utc {
+		logger.timeLocation = time.UTC
+	} else {
+		logger.timeLocation = nil
+	}
+	return logger
+}
+
+func isEpochLayout(layout string) bool {
+	switch layout {
+	case TimestampUnix, TimestampUnixMilli, TimestampUnixMicro, TimestampUnixNano:
+		return true
+	default:
+		return false
+	}
+}
+
+// appendTimestamp formats t with layout, an empty layout meaning time.RFC3339, in loc unless nil.
+// Elapsed time is measured from origin or, if zero, the start of the process.
+func appendTimestamp(buf []byte, t time.Time, layout string, loc *time.Location, origin time.Time) []byte {
+	switch layout {
+	case "":
+		layout = time.RFC3339
+	case TimestampElapsed:
+		if origin.IsZero() {
+			origin = processStart
+		}
+		return appendElapsed(buf, t.Sub(origin))
+	case TimestampUnix:
+		return strconv.AppendInt(buf, t.Unix(), 10)
+	case TimestampUnixMilli:
+		return strconv.AppendInt(buf, t.UnixMilli(), 10)
+	case TimestampUnixMicro:
+		return strconv.AppendInt(buf, t.UnixMicro(), 10)
+	case TimestampUnixNano:
+		return strconv.AppendInt(buf, t.UnixNano(), 10)
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t.AppendFormat(buf, layout)
+}
+
+// appendElapsed writes d as signed seconds with at least four integer digits and milliseconds.
+func appendElapsed(buf []byte, d time.Duration) []byte {
+	if d < 0 {
+		buf = append(buf, '-')
+		d = -d
+	} else {
+		buf = append(buf, '+')
+	}
+	millis := d.Milliseconds()
+	seconds := strconv.FormatInt(millis/1000, 10)
+	for i := len(seconds); i < 4; i++ {
+		buf = append(buf, '0')
+	}
+	buf = append(buf, seconds...)
+	fraction := strconv.FormatInt(1000+millis%1000, 10)
+	buf = append(buf, '.')
+	buf = append(buf, fraction[1:]...)
+	return append(buf, 's')
+}
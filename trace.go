@@ -0,0 +1,92 @@
+package go_logger
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// SpanContext identifies the active span of a distributed trace by hex encoded IDs.
+type SpanContext struct {
+	TraceID string
+	SpanID  string
+}
+
+func (span SpanContext) Valid() bool {
+	return span.TraceID != "" && span.SpanID != ""
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpan returns a context carrying span, for tracing libraries without a registered extractor.
+func ContextWithSpan(ctx context.Context, span SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+var (
+	spanExtractorsMutex sync.Mutex
+	spanExtractors      atomic.Pointer[[]func(context.Context) SpanContext]
+)
+
+// RegisterSpanExtractor adds a function returning the active span of a context. With OpenTelemetry:
+//
+//	go_logger.RegisterSpanExtractor(func(ctx context.Context) go_logger.SpanContext {
+//		span := trace.SpanContextFromContext(ctx)
+//		if !span.IsValid() {
+//			return go_logger.SpanContext{}
+//		}
+//		return go_logger.SpanContext{TraceID: span.TraceID().String(), SpanID: span.SpanID().String()}
+//	})
+//
+// Events logged with a context carrying a valid span get the fields trace_id and span_id.
+func RegisterSpanExtractor(extractor func(context.Context) SpanContext) {
+	spanExtractorsMutex.Lock()
+	defer spanExtractorsMutex.Unlock()
+	var extractors []func(context.Context) SpanContext
+	if current := spanExtractors.Load(); current != nil {
+		extractors = append(extractors, *current...)
+	}
+	extractors = append(extractors, extractor)
+	spanExtractors.Store(&extractors)
+}
+
+// SpanFromContext returns the span stored by ContextWithSpan or found by a registered extractor.
+func SpanFromContext(ctx context.Context) (SpanContext, bool) {
+	if ctx == nil {
+		return SpanContext{}, false
+	}
+	if span, ok := ctx.Value(spanContextKey{}).(SpanContext); ok && span.Valid() {
+		return span, true
+	}
+	if extractors := spanExtractors.Load(); extractors != nil {
+		for _, extractor := range *extractors {
+			if span := extractor(ctx); span.Valid() {
+				return span, true
+			}
+		}
+	}
+	return SpanContext{}, false
+}
+
+// contextFields returns the fields of ctx followed by trace_id and span_id of the active span. Keys
+// already among the fields of ctx, e.g. a trace_id set by ContextWithTraceID, are not added again.
+func contextFields(ctx context.Context) []Field {
+	fields := ContextFields(ctx)
+	span, ok := SpanFromContext(ctx)
+	if !ok {
+		return fields
+	}
+	fields = fields[:len(fields):len(fields)]
+	if !hasField(fields, TraceIDKey) {
+		fields = append(fields, String(TraceIDKey, span.TraceID))
+	}
+	if !hasField(fields, SpanIDKey) {
+		fields = append(fields, String(SpanIDKey, span.SpanID))
+	}
+	return fields
+}
+
+func hasField(fields []Field, key string) bool {
+	return slices.ContainsFunc(fields, func(field Field) bool { return field.Key == key })
+}
@@ -0,0 +1,96 @@
+package go_logger
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+const truncationMarker = "..."
+
+// MaxMessageLength truncates messages, errors and field values longer than n bytes to n bytes ending
+// in "..." and marks such events with the field truncated=true, protecting collectors from payloads of
+// megabytes. Truncation follows redaction, so secrets are matched in full. Zero disables truncation.
+func (logger *Logger) MaxMessageLength(n int) *Logger {
+	logger = logger.derive()
+	logger.maxMessageLength = max(n, 0)
+	return logger
+}
+
+func truncateEvent(event *Event, n int) {
+	message, truncated := truncateValue(event.Message, n)
+	event.Message = message
+	if event.Err != nil {
+		if text, changed := truncateValue(event.Err.Error(), n); changed {
+			event.Err = errors.New(text)
+			truncated = true
+		}
+	}
+	truncate := func(value string) (string, bool) { return truncateValue(value, n) }
+	if fields, changed := transformFields(event.Fields, truncate); changed {
+		event.Fields = fields
+		truncated = true
+	}
+	if truncated {
+		event.Fields = append(event.Fields[:len(event.Fields):len(event.Fields)], Bool("truncated", true))
+	}
+}
+
+// transformFields returns a copy of fields with the string values transformed if any value changed,
+// the shared slice is never modified. Errors and other values are transformed as text.
+func transformFields(fields []Field, transform func(string) (string, bool)) ([]Field, bool) {
+	var result []Field
+	for i, field := range fields {
+		transformed, changed := transformField(field, transform)
+		if !changed {
+			continue
+		}
+		if result == nil {
+			result = make([]Field, len(fields))
+			copy(result, fields)
+		}
+		result[i] = transformed
+	}
+	return result, result != nil
+}
+
+func transformField(field Field, transform func(string) (string, bool)) (Field, bool) {
+	switch field.Type {
+	case StringType:
+		if value, changed := transform(field.String); changed {
+			return String(field.Key, value), true
+		}
+	case ErrorType, AnyType:
+		if field.Interface == nil {
+			return field, false
+		}
+		if value, changed := transform(field.text()); changed {
+			return String(field.Key, value), true
+		}
+	case ObjectType:
+		fields, _ := field.Interface.([]Field)
+		if transformed, changed := transformFields(fields, transform); changed {
+			return Object(field.Key, transformed...), true
+		}
+	case ArrayType:
+		values, _ := field.Interface.([]Field)
+		if transformed, changed := transformFields(values, transform); changed {
+			return Array(field.Key, transformed...), true
+		}
+	}
+	return field, false
+}
+
+// truncateValue cuts value to at most n bytes including the marker, without splitting a UTF-8 sequence.
+func truncateValue(value string, n int) (string, bool) {
+	if len(value) <= n {
+		return value, false
+	}
+	if n <= len(truncationMarker) {
+		return truncationMarker[:n], true
+	}
+	end := n - len(truncationMarker)
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+	return value[:end] + truncationMarker, true
+}
@@ -0,0 +1,97 @@
+package go_logger_test
+
+import (
+	"errors"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestMaxMessageLength(t *testing.T) {
+	tests := []struct {
+		name          string
+		max           int
+		message       string
+		err           error
+		field         golog.Field
+		wantMessage   string
+		wantErr       string
+		wantField     string
+		wantTruncated bool
+	}{
+		{
+			name:        "short",
+			max:         10,
+			message:     "short",
+			field:       golog.String("k", "v"),
+			wantMessage: "short",
+			wantField:   "v",
+		},
+		{
+			name:          "message",
+			max:           10,
+			message:       "0123456789abc",
+			field:         golog.String("k", "v"),
+			wantMessage:   "0123456...",
+			wantField:     "v",
+			wantTruncated: true,
+		},
+		{
+			name:          "error and field",
+			max:           6,
+			message:       "m",
+			err:           errors.New("connection reset"),
+			field:         golog.String("body", "abcdefgh"),
+			wantMessage:   "m",
+			wantErr:       "con...",
+			wantField:     "abc...",
+			wantTruncated: true,
+		},
+		{
+			name:          "utf-8 boundary",
+			max:           7,
+			message:       "äöüß",
+			field:         golog.String("k", "v"),
+			wantMessage:   "äö...",
+			wantField:     "v",
+			wantTruncated: true,
+		},
+		{
+			name:          "shorter than the marker",
+			max:           2,
+			message:       "abc",
+			field:         golog.String("k", "v"),
+			wantMessage:   "..",
+			wantField:     "v",
+			wantTruncated: true,
+		},
+		{
+			name:        "disabled",
+			message:     "0123456789abc",
+			field:       golog.String("k", "0123456789abc"),
+			wantMessage: "0123456789abc",
+			wantField:   "0123456789abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, observer := logtest.NewObservedLogger(golog.INFO)
+			logger.MaxMessageLength(tt.max).With(tt.field).WithError(tt.err).Info(tt.message)
+			event := observer.All()[0]
+			if event.Message != tt.wantMessage {
+				t.Errorf("message %q, want %q", event.Message, tt.wantMessage)
+			}
+			if tt.wantErr != "" && (event.Err == nil || event.Err.Error() != tt.wantErr) {
+				t.Errorf("error %v, want %q", event.Err, tt.wantErr)
+			}
+			if event.Fields[0].String != tt.wantField {
+				t.Errorf("field %q, want %q", event.Fields[0].String, tt.wantField)
+			}
+			truncated := observer.FilterField(golog.Bool("truncated", true)).Len() == 1
+			if truncated != tt.wantTruncated {
+				t.Errorf("truncated %v, want %v", truncated, tt.wantTruncated)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package go_logger
+
+import "sync/atomic"
+
+// WriteError is a failed delivery of an event to the output or a sink of a logger.
+type WriteError struct {
+	Err   error
+	Event *Event
+}
+
+var (
+	writeErrors       atomic.Uint64
+	writeErrorChannel = make(chan WriteError, 64)
+)
+
+// OnWriteError sets a callback invoked synchronously for every failed write of this logger and its
+// children. The event must not be retained after the callback returns.
+func (logger *Logger) OnWriteError(callback func(error, *Event)) *Logger {
+	logger = logger.derive()
+	logger.onWriteError = callback
+	return logger
+}
+
+// WriteErrors returns the number of failed writes of all loggers since start.
+func WriteErrors() uint64 {
+	return writeErrors.Load()
+}
+
+// WriteErrorChannel returns a buffered channel receiving the failed writes of all loggers. Errors are
+// dropped if nobody reads the channel and it is full, but always counted by WriteErrors.
+func WriteErrorChannel() <-chan WriteError {
+	return writeErrorChannel
+}
+
+// reportWriteError counts a failed write and sends it to the write error channel.
+func reportWriteError(err error, event *Event) {
+	writeErrors.Add(1)
+	select {
+	case writeErrorChannel <- WriteError{Err: err, Event: event.Clone()}:
+	default:
+	}
+}
+
+func (logger *Logger) writeError(err error, event *Event) {
+	reportWriteError(err, event)
+	logger.stats.writeErrors.Add(1)
+	if logger.onWriteError != nil {
+		logger.onWriteError(err, event)
+	}
+}
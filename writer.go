@@ -0,0 +1,76 @@
+package go_logger
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+)
+
+// maxLineLength limits the buffered partial line of a Writer; longer lines are split.
+const maxLineLength = 64 * 1024
+
+// Writer returns a writer logging every line written to it as event at level, e.g. for the output of
+// exec.Cmd. Partial lines are buffered until completed by a later write or Close. A trailing "\r" is
+// removed from each line, empty lines are skipped.
+func (logger *Logger) Writer(level Level) io.WriteCloser {
+	return &lineWriter{logger: logger, level: level}
+}
+
+type lineWriter struct {
+	mutex  sync.Mutex
+	logger *Logger
+	level  Level
+	buf    []byte
+	closed bool
+}
+
+func (writer *lineWriter) Write(p []byte) (int, error) {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if writer.closed {
+		return 0, os.ErrClosed
+	}
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			writer.buf = append(writer.buf, p...)
+			for len(writer.buf) >= maxLineLength {
+				writer.logLine(writer.buf[:maxLineLength])
+				writer.buf = append(writer.buf[:0], writer.buf[maxLineLength:]...)
+			}
+			break
+		}
+		if len(writer.buf) > 0 {
+			writer.buf = append(writer.buf, p[:i]...)
+			writer.logLine(writer.buf)
+			writer.buf = writer.buf[:0]
+		} else {
+			writer.logLine(p[:i])
+		}
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+func (writer *lineWriter) logLine(line []byte) {
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+	if len(line) == 0 || !writer.logger.enabled(writer.level) {
+		return
+	}
+	writer.logger.log(createEvent(writer.level, string(line), nil))
+}
+
+// Close logs the remaining partial line. Later writes fail.
+func (writer *lineWriter) Close() error {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if writer.closed {
+		return nil
+	}
+	writer.closed = true
+	writer.logLine(writer.buf)
+	writer.buf = nil
+	return nil
+}
@@ -0,0 +1,61 @@
+package go_logger_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	golog "github.com/jeschu/go-logger"
+	"github.com/jeschu/go-logger/logtest"
+)
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   []string
+	}{
+		{name: "lines", writes: []string{"a\nb\n"}, want: []string{"a", "b"}},
+		{name: "partial lines", writes: []string{"he", "llo\nwor", "ld\n"}, want: []string{"hello", "world"}},
+		{name: "crlf and empty lines", writes: []string{"a\r\n\n\r\nb\n"}, want: []string{"a", "b"}},
+		{name: "flushed on close", writes: []string{"a\nrest"}, want: []string{"a", "rest"}},
+		{name: "long partial line split", writes: []string{strings.Repeat("x", 64*1024+1), "\n"}, want: []string{strings.Repeat("x", 64*1024), "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, observer := logtest.NewObservedLogger(golog.INFO)
+			writer := logger.Writer(golog.WARN)
+			for _, data := range tt.writes {
+				if n, err := writer.Write([]byte(data)); n != len(data) || err != nil {
+					t.Fatalf("Write = %d, %v", n, err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, event := range observer.All() {
+				if event.Level != golog.WARN {
+					t.Errorf("logged at %v, want WARN", event.Level)
+				}
+				got = append(got, event.Message)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("logged %d lines %.40q, want %d lines %.40q", len(got), got, len(tt.want), tt.want)
+			}
+			if _, err := writer.Write([]byte("late\n")); err == nil {
+				t.Error("Write after Close succeeded")
+			}
+		})
+	}
+}
+
+func TestWriterDisabledLevel(t *testing.T) {
+	logger, observer := logtest.NewObservedLogger(golog.INFO)
+	writer := logger.Writer(golog.DEBUG)
+	_, _ = writer.Write([]byte("hidden\n"))
+	_ = writer.Close()
+	if observer.Len() != 0 {
+		t.Errorf("logged %v below the level", observer.All())
+	}
+}